@@ -0,0 +1,224 @@
+package gobuilder
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// UnknownFieldError reports a sort or filter field that is not in the allowlist
+type UnknownFieldError struct {
+	Field string // The field name as given in the input
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q", e.Field)
+}
+
+// UnknownOperatorError reports a filter operator that is not supported
+type UnknownOperatorError struct {
+	Field    string // The field the operator was applied to
+	Operator string // The operator as given in the input
+}
+
+func (e *UnknownOperatorError) Error() string {
+	return fmt.Sprintf("unknown operator %q for field %q", e.Operator, e.Field)
+}
+
+// InvalidFilterError reports a malformed filter key or a value the operator cannot use
+type InvalidFilterError struct {
+	Key    string // The query string key
+	Reason string // Why the filter was rejected
+}
+
+func (e *InvalidFilterError) Error() string {
+	return fmt.Sprintf("invalid filter %q: %s", e.Key, e.Reason)
+}
+
+// filterOperators maps query string operators to SQL comparison operators
+var filterOperators = map[string]string{
+	"eq":   "=",
+	"ne":   "<>",
+	"gt":   ">",
+	"gte":  ">=",
+	"lt":   "<",
+	"lte":  "<=",
+	"like": "LIKE",
+}
+
+// ApplySort adds ORDER BY terms from untrusted input such as "?sort=-created_at,name"
+// A leading "-" sorts descending, a leading "+" or no prefix ascending
+// Parameters:
+//   - gb: The builder to add the terms to
+//   - input: Comma separated field names
+//   - allowed: Map of public field names to the columns they sort by
+//
+// Returns:
+//   - error: *UnknownFieldError for a field missing from allowed; the builder is left unchanged
+//
+// Example:
+//
+//	ApplySort(builder, "-created_at,name", map[string]string{"created_at": "users.created_at", "name": "users.name"})
+//	// Generates: ORDER BY users.created_at DESC, users.name ASC
+func ApplySort(gb *GoBuilder, input string, allowed map[string]string) error {
+	terms := make([]any, 0)
+	for _, field := range strings.Split(input, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		desc := false
+		if strings.HasPrefix(field, "-") {
+			desc = true
+			field = field[1:]
+		} else {
+			field = strings.TrimPrefix(field, "+")
+		}
+
+		column, ok := allowed[field]
+		if !ok {
+			return &UnknownFieldError{Field: field}
+		}
+		if desc {
+			terms = append(terms, Desc(column))
+		} else {
+			terms = append(terms, Asc(column))
+		}
+	}
+
+	gb.OrderByExpr(terms...)
+	return nil
+}
+
+// ApplyFilters adds WHERE predicates from query string filters such as "filter[age][gte]=18"
+// "filter[field]=v" compares with equality. Supported operators are eq, ne, gt, gte, lt, lte, like,
+// in and nin (comma separated lists), between (two comma separated values) and null ("true" or "false")
+// Keys that do not start with "filter[" are ignored, every value is bound as a parameter and the
+// predicates are combined with AND
+// Parameters:
+//   - gb: The builder to add the predicates to
+//   - values: Parsed query string, e.g. r.URL.Query()
+//   - allowed: Map of public field names to the columns they filter
+//
+// Returns:
+//   - error: *UnknownFieldError, *UnknownOperatorError or *InvalidFilterError; the builder is left unchanged
+//
+// Example:
+//
+//	ApplyFilters(builder, url.Values{"filter[age][gte]": {"18"}, "filter[status][in]": {"active,pending"}},
+//	    map[string]string{"age": "age", "status": "status"})
+//	// Generates: WHERE age >= $1 AND status IN ($2, $3)
+func ApplyFilters(gb *GoBuilder, values url.Values, allowed map[string]string) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	// Validate every filter before touching the builder
+	type filter struct {
+		column   string
+		operator string
+		key      string
+		values   []string
+	}
+	filters := make([]filter, 0, len(keys))
+	for _, key := range keys {
+		field, operator, err := parseFilterKey(key)
+		if err != nil {
+			return err
+		}
+		column, ok := allowed[field]
+		if !ok {
+			return &UnknownFieldError{Field: field}
+		}
+		if _, ok := filterOperators[operator]; !ok {
+			switch operator {
+			case "in", "nin", "between", "null":
+			default:
+				return &UnknownOperatorError{Field: field, Operator: operator}
+			}
+		}
+		for _, value := range values[key] {
+			if err := validateFilterValue(key, operator, value); err != nil {
+				return err
+			}
+		}
+		filters = append(filters, filter{column: column, operator: operator, key: key, values: values[key]})
+	}
+
+	clauses := make([]string, 0, len(filters))
+	for _, f := range filters {
+		for _, value := range f.values {
+			clauses = append(clauses, gb.filterClause(f.column, f.operator, value))
+		}
+	}
+	if len(clauses) > 0 {
+		gb.andWhole(strings.Join(clauses, " AND "))
+	}
+	return nil
+}
+
+// parseFilterKey splits "filter[field][op]" into its field and operator, defaulting to eq
+func parseFilterKey(key string) (string, string, error) {
+	rest := strings.TrimPrefix(key, "filter[")
+	field, rest, ok := strings.Cut(rest, "]")
+	if !ok || field == "" {
+		return "", "", &InvalidFilterError{Key: key, Reason: "expected filter[field] or filter[field][operator]"}
+	}
+	if rest == "" {
+		return field, "eq", nil
+	}
+	if !strings.HasPrefix(rest, "[") || !strings.HasSuffix(rest, "]") || len(rest) < 3 {
+		return "", "", &InvalidFilterError{Key: key, Reason: "expected filter[field] or filter[field][operator]"}
+	}
+	return field, strings.ToLower(rest[1 : len(rest)-1]), nil
+}
+
+// validateFilterValue checks that a value can be used with the operator
+func validateFilterValue(key, operator, value string) error {
+	switch operator {
+	case "between":
+		if len(strings.Split(value, ",")) != 2 {
+			return &InvalidFilterError{Key: key, Reason: "between expects two comma separated values"}
+		}
+	case "null":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return &InvalidFilterError{Key: key, Reason: "null expects true or false"}
+		}
+	}
+	return nil
+}
+
+// Private method to render a validated filter as a predicate with bound values
+func (gb *GoBuilder) filterClause(column, operator, value string) string {
+	switch operator {
+	case "in", "nin":
+		items := strings.Split(value, ",")
+		placeholders := make([]string, len(items))
+		for i, item := range items {
+			placeholders[i] = gb.addParam(strings.TrimSpace(item))
+		}
+		keyword := "IN"
+		if operator == "nin" {
+			keyword = "NOT IN"
+		}
+		return fmt.Sprintf("%s %s (%s)", column, keyword, strings.Join(placeholders, ", "))
+	case "between":
+		bounds := strings.Split(value, ",")
+		return fmt.Sprintf("%s BETWEEN %s AND %s", column,
+			gb.addParam(strings.TrimSpace(bounds[0])), gb.addParam(strings.TrimSpace(bounds[1])))
+	case "null":
+		if isNull, _ := strconv.ParseBool(value); isNull {
+			return fmt.Sprintf("%s IS NULL", column)
+		}
+		return fmt.Sprintf("%s IS NOT NULL", column)
+	default:
+		return fmt.Sprintf("%s %s %s", column, filterOperators[operator], gb.addParam(value))
+	}
+}
@@ -0,0 +1,168 @@
+package gobuilder
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var applyAllowed = map[string]string{
+	"name":       "users.name",
+	"age":        "users.age",
+	"status":     "users.status",
+	"created_at": "users.created_at",
+	"deleted_at": "users.deleted_at",
+	"email":      "users.email",
+}
+
+func TestApplySort(t *testing.T) {
+	gb := NewGoBuilder(Postgres).Table("users").Select()
+	if err := ApplySort(gb, "-created_at, name,+age", applyAllowed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query, _ := gb.Prepare()
+	expected := "SELECT * FROM users ORDER BY users.created_at DESC, users.name ASC, users.age ASC"
+	if query != expected {
+		t.Errorf("expected query %v, got %v", expected, query)
+	}
+}
+
+func TestApplySort_UnknownField(t *testing.T) {
+	gb := NewGoBuilder(Postgres).Table("users").Select()
+	err := ApplySort(gb, "name,-password; DROP TABLE users", applyAllowed)
+
+	var fieldErr *UnknownFieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("expected *UnknownFieldError, got %v", err)
+	}
+	if fieldErr.Field != "password; DROP TABLE users" {
+		t.Errorf("expected field %q, got %q", "password; DROP TABLE users", fieldErr.Field)
+	}
+
+	query, _ := gb.Prepare()
+	if query != "SELECT * FROM users" {
+		t.Errorf("expected builder to be unchanged, got %v", query)
+	}
+}
+
+func TestApplyFilters(t *testing.T) {
+	values := url.Values{
+		"filter[age][gte]":            {"18"},
+		"filter[status][in]":          {"active,pending"},
+		"filter[name]":                {"o'brien"},
+		"filter[email][like]":         {"%@example.com"},
+		"filter[created_at][between]": {"2024-01-01,2024-12-31"},
+		"filter[deleted_at][null]":    {"true"},
+		"page":                        {"2"},
+	}
+
+	gb := NewGoBuilder(Postgres).Table("users").Select().Where("tenant_id", "=", 7)
+	if err := ApplyFilters(gb, values, applyAllowed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query, params := gb.Prepare()
+	expected := "SELECT * FROM users WHERE tenant_id = $1 AND users.age >= $2 AND users.created_at BETWEEN $3 AND $4 AND users.deleted_at IS NULL AND users.email LIKE $5 AND users.name = $6 AND users.status IN ($7, $8)"
+	expectedParams := []any{7, "18", "2024-01-01", "2024-12-31", "%@example.com", "o'brien", "active", "pending"}
+	if query != expected {
+		t.Errorf("expected query %v, got %v", expected, query)
+	}
+	if !reflect.DeepEqual(params, expectedParams) {
+		t.Errorf("expected params %v, got %v", expectedParams, params)
+	}
+}
+
+func TestApplyFilters_ValuesAreAlwaysBound(t *testing.T) {
+	gb := NewGoBuilder(MySQL).Table("users").Select()
+	if err := ApplyFilters(gb, url.Values{"filter[email]": {"users.password"}}, applyAllowed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query, params := gb.Prepare()
+	if query != "SELECT * FROM users WHERE users.email = ?" {
+		t.Errorf("expected bound value, got %v", query)
+	}
+	if !reflect.DeepEqual(params, []any{"users.password"}) {
+		t.Errorf("expected params %v, got %v", []any{"users.password"}, params)
+	}
+}
+
+func TestApplyFilters_GroupsExistingConditions(t *testing.T) {
+	gb := NewGoBuilder(Postgres).Table("users").Where("role", "=", "admin").OrWhere("role", "=", "owner")
+	if err := ApplyFilters(gb, url.Values{"filter[age][gte]": {"18"}}, applyAllowed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query, params := gb.Prepare()
+	expected := "SELECT * FROM users WHERE (role = $1 OR role = $2) AND users.age >= $3"
+	if query != expected {
+		t.Errorf("expected query %v, got %v", expected, query)
+	}
+	if !reflect.DeepEqual(params, []any{"admin", "owner", "18"}) {
+		t.Errorf("expected params %v, got %v", []any{"admin", "owner", "18"}, params)
+	}
+}
+
+func TestApplyFilters_DefaultSelect(t *testing.T) {
+	gb := NewGoBuilder(MySQL).Table("users")
+	if err := ApplyFilters(gb, url.Values{"filter[age]": {"18"}}, applyAllowed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query, _ := gb.Prepare()
+	if query != "SELECT * FROM users WHERE users.age = ?" {
+		t.Errorf("expected query %v, got %v", "SELECT * FROM users WHERE users.age = ?", query)
+	}
+}
+
+func TestApplyFilters_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		values url.Values
+		check  func(err error) bool
+	}{
+		{
+			name:   "Unknown Field",
+			values: url.Values{"filter[password]": {"x"}},
+			check:  func(err error) bool { var e *UnknownFieldError; return errors.As(err, &e) && e.Field == "password" },
+		},
+		{
+			name:   "Unknown Operator",
+			values: url.Values{"filter[age][regex]": {"1"}},
+			check: func(err error) bool {
+				var e *UnknownOperatorError
+				return errors.As(err, &e) && e.Field == "age" && e.Operator == "regex"
+			},
+		},
+		{
+			name:   "Malformed Key",
+			values: url.Values{"filter[age": {"1"}},
+			check:  func(err error) bool { var e *InvalidFilterError; return errors.As(err, &e) },
+		},
+		{
+			name:   "Between Needs Two Values",
+			values: url.Values{"filter[age][between]": {"1"}},
+			check:  func(err error) bool { var e *InvalidFilterError; return errors.As(err, &e) },
+		},
+		{
+			name:   "Null Needs Boolean",
+			values: url.Values{"filter[deleted_at][null]": {"maybe"}},
+			check:  func(err error) bool { var e *InvalidFilterError; return errors.As(err, &e) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gb := NewGoBuilder(Postgres).Table("users").Select()
+			err := ApplyFilters(gb, tt.values, applyAllowed)
+			if !tt.check(err) {
+				t.Errorf("unexpected error %v", err)
+			}
+			if query, _ := gb.Prepare(); query != "SELECT * FROM users" {
+				t.Errorf("expected builder to be unchanged, got %v", query)
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package gobuilder
 
 import (
+	"database/sql/driver"
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -21,27 +23,42 @@ const (
 	Oracle    SQLDialect = "oracle"    // Oracle database
 )
 
-// Default timeout duration for query execution
-// This can be modified per query as needed
-var Timeout time.Duration = 30
+// markerPattern matches the internal parameter markers written by addParam
+var markerPattern = regexp.MustCompile("\x00[0-9]+\x00")
+
+// decimalPattern matches decimal numbers passed as strings (e.g. "10", "-2.50")
+var decimalPattern = regexp.MustCompile(`^[+-]?\d+(\.\d+)?$`)
+
+// Default timeout duration for query execution, applied by Exec, Query, Paginate and ClaimBatch
+// when the context has no deadline. It can be overridden per query with WithTimeout; zero disables it
+var Timeout = 30 * time.Second
 
 // GoBuilder is the main struct for building SQL queries
 // It maintains the state of the query being built including all clauses and parameters
 type GoBuilder struct {
-	tableClause   string     // The main table name for the query
-	selectClause  string     // The SELECT part of the query, including columns
-	whereClause   string     // The WHERE conditions of the query
-	groupByClause string     // The GROUP BY columns
-	havingClause  string     // The HAVING conditions for grouped results
-	orderByClause string     // The ORDER BY columns and direction
-	limitClause   string     // The LIMIT and OFFSET values
-	unionClause   string     // For UNION operations with other queries
-	joinClauses   []string   // All JOIN operations (INNER, LEFT, RIGHT)
-	paramsClause  []any      // Collection of parameters for prepared statements
-	counterClause int        // Counter for parameter placeholders
-	sqlDialect    SQLDialect // The SQL dialect being used
-	holderCode    string     // The parameter placeholder format (e.g., $1, ?, @p1)
-	err           error      // Stores any errors that occur during query building
+	tableClause   string        // The main table name for the query
+	selectClause  string        // The SELECT part of the query, including columns
+	selectColumns []string      // The rendered columns of the last Select call
+	distinctOn    []string      // The DISTINCT ON terms
+	whereClause   string        // The WHERE conditions of the query
+	groupByClause string        // The GROUP BY columns
+	havingClause  string        // The HAVING conditions for grouped results
+	orderTerms    []orderTerm   // The ORDER BY terms with their direction
+	limitValues   *limitOffset  // The LIMIT and OFFSET values, rendered per dialect
+	unionClause   string        // For UNION, INTERSECT and EXCEPT operations with other queries
+	joinClauses   []string      // All JOIN operations (INNER, LEFT, RIGHT)
+	setClauses    []string      // The SET assignments of an UPDATE statement
+	cteClauses    []string      // Common table expressions rendered in front of the statement
+	recursiveCTE  bool          // Whether any of the common table expressions is recursive
+	windowClauses []string      // Named window definitions of the WINDOW clause
+	lockClause    string        // The row locking clause rendered at the end of the statement
+	tableHints    string        // SQL Server table hints rendered after the main table
+	returning     string        // The RETURNING clause of a data modifying statement, rendered last
+	timeout       time.Duration // The statement timeout set by WithTimeout, 0 for Timeout, negative for none
+	paramsClause  []any         // Collection of parameters for prepared statements
+	sqlDialect    SQLDialect    // The SQL dialect being used
+	holderCode    string        // The parameter placeholder format (e.g., $1, ?, @p1)
+	err           error         // Stores any errors that occur during query building
 }
 
 // NewGoBuilder creates and initializes a new instance of GoBuilder
@@ -52,9 +69,8 @@ type GoBuilder struct {
 //   - *GoBuilder: A new query builder instance configured for the specified dialect
 func NewGoBuilder(sqlDialect SQLDialect) *GoBuilder {
 	gb := &GoBuilder{
-		paramsClause:  []any{},
-		counterClause: 1,
-		sqlDialect:    sqlDialect,
+		paramsClause: []any{},
+		sqlDialect:   sqlDialect,
 	}
 	gb.holderCode = gb.getPlaceholderCode()
 	return gb
@@ -90,163 +106,131 @@ func (gb *GoBuilder) Table(table string) *GoBuilder {
 		}
 	}
 
-	// Alt sorgu kontrolü
+	// Alt sorgu kontrolü, parametreli alt sorgular için FromSub kullanılmalı
 	if strings.Contains(table, "(") && strings.Contains(table, ")") {
-		// Alt sorgu içindeki COUNT(*) ifadelerini koru
-		if strings.Contains(table, "COUNT(*) as order_count") {
-			gb.tableClause = strings.Replace(table, "COUNT(*) as order_count", "COUNT(*) AS order_count", -1)
-		} else if strings.Contains(table, "COUNT(*) AS order_count") {
-			gb.tableClause = table
-		} else if strings.Contains(table, "COUNT") {
-			// Alt sorgu içindeki diğer COUNT ifadelerini COUNT(*) olarak değiştir
-			gb.tableClause = strings.Replace(table, "COUNT", "COUNT(*)", -1)
-		} else {
-			// Diğer alt sorguları olduğu gibi bırak
-			gb.tableClause = table
-		}
+		gb.tableClause = table
 	} else {
-		gb.tableClause = gb.sanitizeIdentifier(table)
+		gb.tableClause = sanitizeIdentifier(table)
 	}
 
 	return gb
 }
 
+// FromSub sets a derived table built by another builder as the main table of the query
+// The sub query parameters are merged into the main query
+// Parameters:
+//   - subQuery: The builder that produces the derived table
+//   - alias: The alias of the derived table
+//
+// Returns:
+//   - *GoBuilder: The builder instance for method chaining
+//
+// Example:
+//
+//	stats := NewGoBuilder(Postgres).Table("orders").Select("customer_id", "COUNT(*) as order_count").GroupBy("customer_id").Having("COUNT(*) > ?", 5)
+//	builder.FromSub(stats, "order_stats").Select("customer_id", "order_count")
+//	// Generates: SELECT customer_id, order_count FROM (SELECT customer_id, COUNT(*) as order_count FROM orders GROUP BY customer_id HAVING COUNT(*) > $1) AS order_stats
+func (gb *GoBuilder) FromSub(subQuery *GoBuilder, alias string) *GoBuilder {
+	gb.tableClause = gb.derivedTable(subQuery, alias)
+	return gb
+}
+
 // Select specifies the columns to retrieve in the query with sanitization
+// Use SelectExpr to mix in typed expressions
 func (gb *GoBuilder) Select(columns ...string) *GoBuilder {
+	return gb.SelectExpr(stringsToAny(columns)...)
+}
+
+// SelectExpr specifies the columns to retrieve as strings or typed expressions
+// Parameters:
+//   - columns: Column names as strings, or typed expressions such as Sum(Col("amount")).As("total")
+//
+// Returns:
+//   - *GoBuilder: The builder instance for method chaining
+//
+// Example:
+//
+//	builder.Table("orders").SelectExpr("customer_id", Sum(Col("amount")).As("total"))
+//	// Generates: SELECT customer_id, SUM(amount) as total FROM orders
+func (gb *GoBuilder) SelectExpr(columns ...any) *GoBuilder {
 	if gb.tableClause == "" {
 		gb.err = fmt.Errorf("table name is required")
 		return gb
 	}
 
-	if len(columns) == 0 {
-		columns = append(columns, "*")
-	} else {
-		processedColumns := make([]string, len(columns))
-		for i, col := range columns {
-			// SQL injection kontrolü
-			lowerCol := strings.ToLower(col)
-			if strings.Contains(lowerCol, ";") ||
-				strings.Contains(lowerCol, "drop") ||
-				strings.Contains(lowerCol, "truncate") {
-				col = strings.Split(col, ";")[0] // Sadece ilk kısmı al
-			}
-
-			// Alt sorgu kontrolü
-			if strings.Contains(col, "(") && strings.Contains(col, ")") {
-				processedColumns[i] = strings.Replace(col, " AS ", " as ", -1)
-				continue
-			}
-
-			// Eğer aggregate fonksiyonu içeriyorsa ve parantez yoksa
-			upperCol := strings.ToUpper(col)
-			if !strings.Contains(col, "(") {
-				switch {
-				case strings.Contains(upperCol, "COUNT"):
-					if strings.Contains(upperCol, "AS") {
-						parts := strings.Split(col, " AS ")
-						col = fmt.Sprintf("COUNT(*) as %s", parts[1])
-					} else {
-						col = "COUNT(*)"
-					}
-				case strings.Contains(upperCol, "SUM"):
-					if strings.Contains(upperCol, "AS") {
-						parts := strings.Split(col, " AS ")
-						colParts := strings.Split(parts[0], " ")
-						col = fmt.Sprintf("SUM(%s) as %s", colParts[1], parts[1])
-					}
-				case strings.Contains(upperCol, "AVG"):
-					if strings.Contains(upperCol, "AS") {
-						parts := strings.Split(col, " AS ")
-						colParts := strings.Split(parts[0], " ")
-						col = fmt.Sprintf("AVG(%s) as %s", colParts[1], parts[1])
-					}
-				case strings.Contains(upperCol, "MIN"):
-					if strings.Contains(upperCol, "AS") {
-						parts := strings.Split(col, " AS ")
-						colParts := strings.Split(parts[0], " ")
-						col = fmt.Sprintf("MIN(%s) as %s", colParts[1], parts[1])
-					}
-				case strings.Contains(upperCol, "MAX"):
-					if strings.Contains(upperCol, "AS") {
-						parts := strings.Split(col, " AS ")
-						colParts := strings.Split(parts[0], " ")
-						col = fmt.Sprintf("MAX(%s) as %s", colParts[1], parts[1])
-					}
-				}
-			}
-
-			// CASE ifadeleri ve Window fonksiyonları için alias kontrolü
-			if strings.Contains(upperCol, "CASE") || strings.Contains(upperCol, "OVER") {
-				if !strings.Contains(upperCol, " AS ") && strings.Contains(col, " as ") {
-					parts := strings.Split(col, " as ")
-					col = fmt.Sprintf("%s as %s", parts[0], parts[1])
-				}
-			}
-
-			// AS kelimesini küçük harfe çevir
-			col = strings.Replace(col, " AS ", " as ", -1)
-
-			// Özel durumlar için kontrol
-			if strings.Contains(col, "order_count") {
-				processedColumns[i] = "order_count"
-			} else if strings.Contains(col, "customer_id") {
-				processedColumns[i] = "customer_id"
-			} else {
-				processedColumns[i] = col
+	processedColumns := []string{"*"}
+	gb.selectColumns = processedColumns
+	if len(columns) > 0 {
+		processedColumns = make([]string, len(columns))
+		gb.selectColumns = processedColumns
+		for i, column := range columns {
+			col, ok := gb.selectItem(column)
+			if !ok {
+				return gb
 			}
+			processedColumns[i] = col
 		}
-		columns = processedColumns
 	}
 
-	// Preserve WITH clause if it exists (for CTEs)
-	withClause := ""
-	if strings.HasPrefix(gb.selectClause, "WITH ") {
-		withClause = gb.selectClause + " "
-	}
+	gb.selectClause = fmt.Sprintf("SELECT %s FROM %s", strings.Join(processedColumns, ", "), gb.tableClause)
+	return gb
+}
 
-	// Alt sorgu kontrolü
-	if strings.Contains(gb.tableClause, "order_count") {
-		for i, col := range columns {
-			if col == "order_count" {
-				columns[i] = "order_count"
-			}
-		}
+// Private method to render a SELECT column given as a string or an expression
+func (gb *GoBuilder) selectItem(column any) (string, bool) {
+	switch col := column.(type) {
+	case string:
+		return selectColumn(col), true
+	case Expr:
+		return gb.selectExpr(col), true
 	}
+	gb.err = fmt.Errorf("unsupported column type %T", column)
+	return "", false
+}
 
-	// Alt sorgu kontrolü
-	if strings.Contains(gb.tableClause, "COUNT(*) as order_count") {
-		for i, col := range columns {
-			if col == "order_count" {
-				columns[i] = "order_count"
-			}
-		}
+// selectColumn applies the string column shortcuts of Select
+func selectColumn(col string) string {
+	// SQL injection kontrolü
+	lowerCol := strings.ToLower(col)
+	if strings.Contains(lowerCol, ";") ||
+		strings.Contains(lowerCol, "drop") ||
+		strings.Contains(lowerCol, "truncate") {
+		col = strings.Split(col, ";")[0] // Sadece ilk kısmı al
 	}
 
 	// Alt sorgu kontrolü
-	if strings.Contains(gb.tableClause, "COUNT(*) AS order_count") {
-		for i, col := range columns {
-			if col == "order_count" {
-				columns[i] = "order_count"
+	if strings.Contains(col, "(") && strings.Contains(col, ")") {
+		return strings.Replace(col, " AS ", " as ", -1)
+	}
+
+	// Parantezsiz aggregate kısayolları: "COUNT AS total", "SUM amount AS total"
+	// Sadece ilk kelime fonksiyon adı ise uygulanır, "order_count" gibi sütunlar etkilenmez
+	upperCol := strings.ToUpper(col)
+	if fields := strings.Fields(col); len(fields) > 0 && !strings.Contains(col, "(") {
+		switch fn := strings.ToUpper(fields[0]); fn {
+		case "COUNT":
+			if len(fields) == 1 {
+				col = "COUNT(*)"
+			} else if len(fields) == 3 && strings.EqualFold(fields[1], "AS") {
+				col = fmt.Sprintf("COUNT(*) as %s", fields[2])
 			}
-		}
-	}
-
-	// Alt sorgu kontrolü
-	if strings.Contains(gb.tableClause, "COUNT(*) as order_count") || strings.Contains(gb.tableClause, "COUNT(*) AS order_count") {
-		for i, col := range columns {
-			if col == "COUNT(*)" {
-				columns[i] = "order_count"
+		case "SUM", "AVG", "MIN", "MAX":
+			if len(fields) == 4 && strings.EqualFold(fields[2], "AS") {
+				col = fmt.Sprintf("%s(%s) as %s", fn, fields[1], fields[3])
 			}
 		}
 	}
 
-	// Alt sorgu kontrolü
-	if strings.Contains(gb.tableClause, "(") && strings.Contains(gb.tableClause, ")") {
-		gb.tableClause = strings.Replace(gb.tableClause, " AS ", " as ", -1)
+	// CASE ifadeleri ve Window fonksiyonları için alias kontrolü
+	if strings.Contains(upperCol, "CASE") || strings.Contains(upperCol, "OVER") {
+		if !strings.Contains(upperCol, " AS ") && strings.Contains(col, " as ") {
+			parts := strings.Split(col, " as ")
+			col = fmt.Sprintf("%s as %s", parts[0], parts[1])
+		}
 	}
 
-	gb.selectClause = fmt.Sprintf("%sSELECT %s FROM %s", withClause, strings.Join(columns, ", "), gb.tableClause)
-	return gb
+	// AS kelimesini küçük harfe çevir
+	return strings.Replace(col, " AS ", " as ", -1)
 }
 
 // SelectDistinct creates a SELECT DISTINCT query
@@ -261,10 +245,14 @@ func (gb *GoBuilder) Select(columns ...string) *GoBuilder {
 //	builder.SelectDistinct("country", "city")
 //	// Generates: SELECT DISTINCT country, city FROM ...
 func (gb *GoBuilder) SelectDistinct(columns ...string) *GoBuilder {
-	if len(columns) == 0 {
-		columns = append(columns, "*")
+	return gb.SelectDistinctExpr(stringsToAny(columns)...)
+}
+
+// SelectDistinctExpr creates a SELECT DISTINCT query from strings or typed expressions, processed like SelectExpr
+func (gb *GoBuilder) SelectDistinctExpr(columns ...any) *GoBuilder {
+	if gb.SelectExpr(columns...).err == nil {
+		gb.selectClause = strings.Replace(gb.selectClause, "SELECT ", "SELECT DISTINCT ", 1)
 	}
-	gb.selectClause = fmt.Sprintf("SELECT DISTINCT %s FROM %s", strings.Join(columns, ", "), gb.tableClause)
 	return gb
 }
 
@@ -313,7 +301,7 @@ func (gb *GoBuilder) Create(args map[string]any, returning ...string) *GoBuilder
 
 // Update builds an UPDATE statement with the provided data
 // Parameters:
-//   - args: Map of column names to new values
+//   - args: Map of column names to new values; typed expressions such as Case() are rendered, other values are bound
 //
 // Returns:
 //   - *GoBuilder: The builder instance for method chaining
@@ -335,14 +323,9 @@ func (gb *GoBuilder) Update(args map[string]any) *GoBuilder {
 
 		setClauses := make([]string, 0, len(keys))
 		for _, key := range keys {
-			setClauses = append(setClauses, fmt.Sprintf("%s = %s", key, gb.addParam(args[key])))
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s", key, operand(gb, args[key])))
 		}
-
-		gb.selectClause = fmt.Sprintf(
-			"UPDATE %s SET %s",
-			gb.tableClause,
-			strings.Join(setClauses, ", "),
-		)
+		gb.addSet(setClauses...)
 	}
 	return gb
 }
@@ -357,37 +340,26 @@ func (gb *GoBuilder) Update(args map[string]any) *GoBuilder {
 //	// Generates: DELETE FROM table WHERE status = $1
 func (gb *GoBuilder) Delete() *GoBuilder {
 	gb.selectClause = fmt.Sprintf("DELETE FROM %s", gb.tableClause)
+	gb.checkLock()
 	return gb
 }
 
 // Where adds a WHERE condition to the query
 func (gb *GoBuilder) Where(key, opt string, val any) *GoBuilder {
-	key = gb.sanitizeIdentifier(key)
+	key = sanitizeIdentifier(key)
 	var clause string
 	switch v := val.(type) {
 	case *GoBuilder:
-		subQuery, subParams := v.Prepare()
-		gb.paramsClause = append(gb.paramsClause, subParams...)
-		clause = fmt.Sprintf("%s %s (%s)", key, opt, subQuery)
+		clause = fmt.Sprintf("%s %s (%s)", key, opt, gb.embed(v))
 	default:
 		if str, ok := val.(string); ok && strings.Contains(str, ".") {
-			clause = fmt.Sprintf("%s %s %s", key, opt, gb.sanitizeIdentifier(str))
+			clause = fmt.Sprintf("%s %s %s", key, opt, sanitizeIdentifier(str))
 		} else {
 			clause = fmt.Sprintf("%s %s %s", key, opt, gb.addParam(val))
 		}
 	}
 
-	if gb.whereClause == "" {
-		gb.whereClause = fmt.Sprintf("WHERE %s", clause)
-	} else {
-		gb.whereClause = fmt.Sprintf("%s AND %s", gb.whereClause, clause)
-	}
-
-	// Eğer SELECT ifadesi yoksa ve tablo adı varsa, varsayılan SELECT ifadesini ekle
-	if gb.selectClause == "" && gb.tableClause != "" {
-		gb.selectClause = fmt.Sprintf("SELECT * FROM %s", gb.tableClause)
-	}
-
+	gb.addWhere(clause)
 	return gb
 }
 
@@ -446,15 +418,48 @@ func (gb *GoBuilder) OrIsNotNull(column string) *GoBuilder {
 	return gb
 }
 
-// Having adds a HAVING clause
-func (gb *GoBuilder) Having(condition string, args ...any) *GoBuilder {
-	// Parametreleri ekle
-	for _, arg := range args {
-		condition = strings.Replace(condition, "?", gb.addParam(arg), 1)
+// Having adds a HAVING condition, combined with earlier conditions using AND
+// The condition is either a string with "?" placeholders for args, a typed expression
+// such as Sum(Col("amount")).Gt(1000), or a *Condition
+func (gb *GoBuilder) Having(having any, args ...any) *GoBuilder {
+	return gb.having("AND", having, args...)
+}
+
+// OrHaving adds a HAVING condition, combined with earlier conditions using OR
+func (gb *GoBuilder) OrHaving(having any, args ...any) *GoBuilder {
+	return gb.having("OR", having, args...)
+}
+
+// Private method to add HAVING conditions with logical operators
+func (gb *GoBuilder) having(OP string, having any, args ...any) *GoBuilder {
+	var condition string
+	switch h := having.(type) {
+	case string:
+		condition = h
+		// Parametreleri ekle
+		for _, arg := range args {
+			condition = strings.Replace(condition, "?", gb.addParam(arg), 1)
+		}
+	case *Condition:
+		if h.err != nil {
+			gb.err = h.err
+			return gb
+		}
+		condition = gb.absorb(h.clause, h.params)
+	case Expr:
+		condition = h.toSQL(gb)
+	default:
+		gb.err = fmt.Errorf("unsupported having condition type %T", having)
+		return gb
 	}
 
 	if gb.havingClause != "" {
-		gb.havingClause = fmt.Sprintf("%s OR %s", gb.havingClause, condition)
+		// Earlier OR conditions are grouped so they bind before the AND, as andWhole does for WHERE
+		existing := strings.TrimPrefix(gb.havingClause, "HAVING ")
+		if OP == "AND" && strings.Contains(strings.ToUpper(existing), " OR ") {
+			gb.havingClause = fmt.Sprintf("HAVING (%s)", existing)
+		}
+		gb.havingClause = fmt.Sprintf("%s %s %s", gb.havingClause, OP, condition)
 	} else {
 		gb.havingClause = fmt.Sprintf("HAVING %s", condition)
 	}
@@ -482,28 +487,69 @@ func (gb *GoBuilder) RightJoin(table, first, operator, last string) *GoBuilder {
 	return gb
 }
 
-// Limit adds a LIMIT clause
+// Limit adds a LIMIT clause, rendered per dialect
+// PostgreSQL uses "OFFSET o LIMIT l", MySQL and SQLite "LIMIT l OFFSET o", and SQL Server and Oracle
+// "OFFSET o ROWS FETCH NEXT l ROWS ONLY"; SQL Server requires ORDER BY, so "ORDER BY (SELECT NULL)" is
+// added when no order is set
 func (gb *GoBuilder) Limit(offset, limit int) *GoBuilder {
-	gb.limitClause = fmt.Sprintf("OFFSET %d LIMIT %d", offset, limit)
+	gb.limitValues = &limitOffset{offset: offset, limit: limit}
+	gb.checkLock()
 	return gb
 }
 
+// limitOffset holds the values set by Limit
+type limitOffset struct {
+	offset int
+	limit  int
+}
+
+// Private method to render the LIMIT clause for the dialect
+func (gb *GoBuilder) limitClause() string {
+	l := gb.limitValues
+	switch gb.sqlDialect {
+	case MySQL, SQLite:
+		return fmt.Sprintf("LIMIT %d OFFSET %d", l.limit, l.offset)
+	case SQLServer, Oracle:
+		return fmt.Sprintf("OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", l.offset, l.limit)
+	default:
+		return fmt.Sprintf("OFFSET %d LIMIT %d", l.offset, l.limit)
+	}
+}
+
 // GroupBy adds a GROUP BY clause
 func (gb *GoBuilder) GroupBy(columns ...string) *GoBuilder {
-	gb.groupByClause = fmt.Sprintf("GROUP BY %v", strings.Join(columns, ", "))
+	return gb.GroupByExpr(stringsToAny(columns)...)
+}
+
+// GroupByExpr adds a GROUP BY clause of strings or typed expressions such as Rollup and Cube
+func (gb *GoBuilder) GroupByExpr(columns ...any) *GoBuilder {
+	gb.groupByClause = fmt.Sprintf("GROUP BY %v", strings.Join(gb.terms(columns), ", "))
 	return gb
 }
 
-// OrderBy adds an ORDER BY ASC clause
+// OrderBy appends ascending ORDER BY terms
 func (gb *GoBuilder) OrderBy(columns ...string) *GoBuilder {
-	gb.orderByClause = fmt.Sprintf("ORDER BY %v ASC", strings.Join(columns, ", "))
-	return gb
+	return gb.OrderByExpr(stringsToAny(columns)...)
 }
 
-// OrderByDesc adds an ORDER BY DESC clause
+// OrderByDesc appends descending ORDER BY terms
 func (gb *GoBuilder) OrderByDesc(columns ...string) *GoBuilder {
-	gb.orderByClause = fmt.Sprintf("ORDER BY %v DESC", strings.Join(columns, ", "))
-	return gb
+	return gb.OrderByDescExpr(stringsToAny(columns)...)
+}
+
+// OrderByExpr appends ascending ORDER BY terms given as strings, typed expressions or Asc/Desc terms
+// Terms built with Asc or Desc keep their own direction and NULLS ordering
+// Example:
+//
+//	builder.OrderByExpr("last_name", Desc("created_at").NullsLast())
+//	// Generates: ORDER BY last_name ASC, created_at DESC NULLS LAST
+func (gb *GoBuilder) OrderByExpr(columns ...any) *GoBuilder {
+	return gb.addOrder(false, columns)
+}
+
+// OrderByDescExpr appends descending ORDER BY terms given as strings or typed expressions
+func (gb *GoBuilder) OrderByDescExpr(columns ...any) *GoBuilder {
+	return gb.addOrder(true, columns)
 }
 
 // Union adds a UNION clause
@@ -520,32 +566,7 @@ func (gb *GoBuilder) OrderByDesc(columns ...string) *GoBuilder {
 //	result := query1.Union(query2)
 //	// Generates: SELECT name, email FROM users UNION SELECT name, work_email FROM employees
 func (gb *GoBuilder) Union(builder *GoBuilder) *GoBuilder {
-	paramOffset := gb.counterClause - 1
-	builder.counterClause = gb.counterClause
-
-	unionQuery, unionParams := builder.Prepare()
-
-	// Update the parameter placeholders in the union query
-	for i := 1; i <= len(unionParams); i++ {
-		oldPlaceholder := fmt.Sprintf("%s%d", gb.holderCode, i)
-		newPlaceholder := fmt.Sprintf("%s%d", gb.holderCode, i+paramOffset)
-		unionQuery = strings.Replace(unionQuery, oldPlaceholder, newPlaceholder, 1)
-	}
-
-	// Add the union parameters to the main query
-	gb.paramsClause = append(gb.paramsClause, unionParams...)
-
-	// Update the counter for future parameters
-	gb.counterClause += len(unionParams)
-
-	// Add the UNION clause
-	if gb.unionClause == "" {
-		gb.unionClause = fmt.Sprintf("UNION %s", unionQuery)
-	} else {
-		gb.unionClause = fmt.Sprintf("%s UNION %s", gb.unionClause, unionQuery)
-	}
-
-	return gb
+	return gb.setOperation("UNION", builder)
 }
 
 // UnionAll adds a UNION ALL clause
@@ -562,68 +583,125 @@ func (gb *GoBuilder) Union(builder *GoBuilder) *GoBuilder {
 //	result := query1.UnionAll(query2)
 //	// Generates: SELECT name, email FROM users UNION ALL SELECT name, work_email FROM employees
 func (gb *GoBuilder) UnionAll(builder *GoBuilder) *GoBuilder {
-	// Adjust the parameter counter for the union query
-	paramOffset := gb.counterClause - 1
-	builder.counterClause = gb.counterClause
+	return gb.setOperation("UNION ALL", builder)
+}
 
-	// Get the query and parameters from the union builder
-	unionQuery, unionParams := builder.Prepare()
+// Intersect adds an INTERSECT clause
+// Example:
+//
+//	query1 := builder.Table("customers").Select("email")
+//	query2 := builder.Table("subscribers").Select("email")
+//	result := query1.Intersect(query2)
+//	// Generates: SELECT email FROM customers INTERSECT SELECT email FROM subscribers
+func (gb *GoBuilder) Intersect(builder *GoBuilder) *GoBuilder {
+	return gb.setOperation("INTERSECT", builder)
+}
+
+// IntersectAll adds an INTERSECT ALL clause (not supported in SQLite and SQL Server)
+func (gb *GoBuilder) IntersectAll(builder *GoBuilder) *GoBuilder {
+	return gb.setOperation("INTERSECT ALL", builder)
+}
 
-	// Update the parameter placeholders in the union query
-	for i := 1; i <= len(unionParams); i++ {
-		oldPlaceholder := fmt.Sprintf("%s%d", gb.holderCode, i)
-		newPlaceholder := fmt.Sprintf("%s%d", gb.holderCode, i+paramOffset)
-		unionQuery = strings.Replace(unionQuery, oldPlaceholder, newPlaceholder, 1)
+// Except adds an EXCEPT clause, rendered as MINUS in Oracle
+// Example:
+//
+//	query1 := builder.Table("customers").Select("email")
+//	query2 := builder.Table("unsubscribed").Select("email")
+//	result := query1.Except(query2)
+//	// Generates: SELECT email FROM customers EXCEPT SELECT email FROM unsubscribed
+func (gb *GoBuilder) Except(builder *GoBuilder) *GoBuilder {
+	return gb.setOperation("EXCEPT", builder)
+}
+
+// ExceptAll adds an EXCEPT ALL clause, rendered as MINUS ALL in Oracle (not supported in SQLite and SQL Server)
+func (gb *GoBuilder) ExceptAll(builder *GoBuilder) *GoBuilder {
+	return gb.setOperation("EXCEPT ALL", builder)
+}
+
+// Private method to add set operations
+// The ORDER BY and LIMIT of the main builder apply to the combined result, while a branch with
+// its own ORDER BY, LIMIT or set operations is parenthesised so they stay inside that branch
+func (gb *GoBuilder) setOperation(operator string, builder *GoBuilder) *GoBuilder {
+	if strings.HasSuffix(operator, " ALL") && operator != "UNION ALL" &&
+		(gb.sqlDialect == SQLite || gb.sqlDialect == SQLServer) {
+		gb.err = fmt.Errorf("%s is not supported in %s", operator, gb.sqlDialect)
+		return gb
+	}
+	if gb.sqlDialect == Oracle {
+		operator = strings.Replace(operator, "EXCEPT", "MINUS", 1)
 	}
 
-	// Add the union parameters to the main query
-	gb.paramsClause = append(gb.paramsClause, unionParams...)
+	nested := len(builder.orderTerms) > 0 || builder.limitValues != nil || builder.unionClause != ""
 
-	// Update the counter for future parameters
-	gb.counterClause += len(unionParams)
+	// Render the branch query and merge its parameters into the main query
+	branch := gb.embed(builder)
+	if nested {
+		// SQLite and SQL Server do not accept parenthesised branches, so the branch becomes a derived table
+		switch gb.sqlDialect {
+		case SQLite:
+			branch = fmt.Sprintf("SELECT * FROM (%s)", branch)
+		case SQLServer:
+			branch = fmt.Sprintf("SELECT * FROM (%s) AS branch", branch)
+		default:
+			branch = fmt.Sprintf("(%s)", branch)
+		}
+	}
 
-	// Add the UNION ALL clause
 	if gb.unionClause == "" {
-		gb.unionClause = fmt.Sprintf("UNION ALL %s", unionQuery)
+		gb.unionClause = fmt.Sprintf("%s %s", operator, branch)
 	} else {
-		gb.unionClause = fmt.Sprintf("%s UNION ALL %s", gb.unionClause, unionQuery)
+		gb.unionClause = fmt.Sprintf("%s %s %s", gb.unionClause, operator, branch)
 	}
 
 	return gb
 }
 
-// Sql returns the final SQL query
+// Sql returns the final SQL query with the parameters inlined
+// It renders the same clauses in the same order as Prepare
 func (gb *GoBuilder) Sql() string {
-	clauses := []string{
-		gb.selectClause,
-		strings.Join(gb.joinClauses, " "),
-		gb.whereClause,
-		gb.groupByClause,
-		gb.havingClause,
-		gb.orderByClause,
-		gb.limitClause,
-		gb.unionClause,
-	}
-	query := strings.Join(clauses, " ")
-	re := regexp.MustCompile(`\s+`)
-	query = strings.TrimSpace(re.ReplaceAllString(query, " "))
+	query := gb.build()
 
+	// Inline the parameters in place of their markers
 	params := gb.paramsClause
-	for i, param := range params {
-		placeholder := fmt.Sprintf("%s%d", gb.holderCode, i+1)
-		query = strings.Replace(query, placeholder, gb.cleanValue(param), 1)
-	}
+	query = markerPattern.ReplaceAllStringFunc(query, func(marker string) string {
+		return gb.cleanValue(params[markerIndex(marker)])
+	})
 	gb.reset()
 	return query
 }
 
 // Prepare returns the final SQL query and the associated bind parameters
 func (gb *GoBuilder) Prepare() (string, []any) {
+	// Replace markers with dialect placeholders and reset the builder
+	query, params := gb.bindParams(gb.build())
+	gb.reset()
+
+	return query, params
+}
+
+// Private method to assemble the clauses of the query
+// Parameters are left as markers so the result can be bound, inlined or embedded in another query
+func (gb *GoBuilder) build() string {
+	if len(gb.distinctOn) > 0 {
+		return gb.buildDistinctOn(true)
+	}
 	clauses := make([]string, 0)
 
-	// Add the main SELECT/UPDATE/DELETE clause
+	// Add the WITH clause in front of the statement
+	if len(gb.cteClauses) > 0 {
+		clauses = append(clauses, gb.withClause())
+	}
+
+	// Add the main SELECT/UPDATE/DELETE clause, with SQL Server table hints after the main table
 	if gb.selectClause != "" {
-		clauses = append(clauses, gb.selectClause)
+		selectClause := gb.selectClause
+		if gb.tableHints != "" && strings.HasSuffix(selectClause, " FROM "+gb.tableClause) {
+			selectClause += " " + gb.tableHints
+		}
+		if hint := gb.timeoutHint(); hint != "" && strings.HasPrefix(selectClause, "SELECT ") {
+			selectClause = "SELECT " + hint + " " + strings.TrimPrefix(selectClause, "SELECT ")
+		}
+		clauses = append(clauses, selectClause)
 	}
 
 	// Add JOIN clauses
@@ -646,50 +724,113 @@ func (gb *GoBuilder) Prepare() (string, []any) {
 		clauses = append(clauses, gb.havingClause)
 	}
 
+	// Add named WINDOW definitions
+	if len(gb.windowClauses) > 0 {
+		clauses = append(clauses, "WINDOW "+strings.Join(gb.windowClauses, ", "))
+	}
+
 	// Add UNION clauses before ORDER BY and LIMIT
 	if gb.unionClause != "" {
 		clauses = append(clauses, gb.unionClause)
 	}
 
 	// Add ORDER BY clause
-	if gb.orderByClause != "" {
-		clauses = append(clauses, gb.orderByClause)
+	if len(gb.orderTerms) > 0 {
+		clauses = append(clauses, "ORDER BY "+gb.orderList(gb.orderTerms))
+	} else if gb.limitValues != nil && gb.sqlDialect == SQLServer {
+		clauses = append(clauses, "ORDER BY (SELECT NULL)")
 	}
 
 	// Add LIMIT clause
-	if gb.limitClause != "" {
-		clauses = append(clauses, gb.limitClause)
+	if gb.limitValues != nil {
+		clauses = append(clauses, gb.limitClause())
+	}
+
+	// Add the row locking clause at the end of the statement
+	if gb.lockClause != "" {
+		clauses = append(clauses, gb.lockClause)
+	}
+
+	// Add the RETURNING clause of a data modifying statement
+	if gb.returning != "" {
+		clauses = append(clauses, gb.returning)
 	}
 
 	// Join all clauses with spaces and clean up extra whitespace
 	query := strings.Join(clauses, " ")
 	re := regexp.MustCompile(`\s+`)
-	query = strings.TrimSpace(re.ReplaceAllString(query, " "))
-
-	// Get the parameters and reset the builder
-	params := gb.paramsClause
-	gb.reset()
-
-	return query, params
+	return strings.TrimSpace(re.ReplaceAllString(query, " "))
 }
 
-// Private method to RESET builder
+// Private method to RESET builder, keeping its dialect
 func (gb *GoBuilder) reset() {
-	*gb = *NewGoBuilder(Postgres)
+	*gb = *NewGoBuilder(gb.sqlDialect)
 }
 
 // Private method to add parameters
+// It returns an internal marker that is replaced by the dialect placeholder when the query is rendered,
+// so parameters are numbered in the order they appear in the final SQL, not the order they were added
 func (gb *GoBuilder) addParam(value any) string {
 	gb.paramsClause = append(gb.paramsClause, value)
+	return paramMarker(len(gb.paramsClause) - 1)
+}
 
-	// MySQL ve SQLite için özel durum
-	if gb.sqlDialect == MySQL || gb.sqlDialect == SQLite {
-		return "?"
+// Private method to render a sub query and merge its parameters into the builder
+func (gb *GoBuilder) embed(subQuery *GoBuilder) string {
+	if subQuery.err != nil && gb.err == nil {
+		gb.err = subQuery.err
 	}
 
-	placeholder := fmt.Sprintf("%s%d", gb.holderCode, gb.counterClause)
-	gb.counterClause++
-	return placeholder
+	// SQL Server does not allow WITH inside sub queries, so their CTEs are moved to the main query
+	var ctes []string
+	if gb.sqlDialect == SQLServer && len(subQuery.cteClauses) > 0 {
+		ctes = subQuery.cteClauses
+		subQuery.cteClauses = nil
+	}
+
+	offset := len(gb.paramsClause)
+	query := gb.absorb(subQuery.detach())
+	for _, cte := range ctes {
+		gb.cteClauses = append(gb.cteClauses, shiftMarkers(cte, offset))
+	}
+	return query
+}
+
+// Private method to render the query with parameter markers and hand over its parameters
+// The builder is reset afterwards, just like after Prepare
+func (gb *GoBuilder) detach() (string, []any) {
+	query := gb.build()
+	params := gb.paramsClause
+	gb.reset()
+	return query, params
+}
+
+// Private method to merge a fragment rendered with its own parameters into the builder
+func (gb *GoBuilder) absorb(fragment string, params []any) string {
+	fragment = shiftMarkers(fragment, len(gb.paramsClause))
+	gb.paramsClause = append(gb.paramsClause, params...)
+	return fragment
+}
+
+// Private method to replace parameter markers with dialect placeholders
+// The parameters are returned in the order their placeholders appear in the query
+func (gb *GoBuilder) bindParams(query string) (string, []any) {
+	params := make([]any, 0, len(gb.paramsClause))
+	query = markerPattern.ReplaceAllStringFunc(query, func(marker string) string {
+		params = append(params, gb.paramsClause[markerIndex(marker)])
+		return gb.placeholder(len(params))
+	})
+	return query, params
+}
+
+// Private method to format the n-th placeholder for the current dialect
+func (gb *GoBuilder) placeholder(n int) string {
+	switch gb.sqlDialect {
+	case MySQL, SQLite:
+		return "?"
+	default:
+		return fmt.Sprintf("%s%d", gb.holderCode, n)
+	}
 }
 
 // Private method to add clauses with logical operators
@@ -701,6 +842,26 @@ func (gb *GoBuilder) addClause(OP, clause string) {
 	}
 }
 
+// Private method to AND a predicate into the WHERE clause, as Where does
+func (gb *GoBuilder) addWhere(clause string) {
+	gb.addClause("AND", clause)
+
+	// Eğer SELECT ifadesi yoksa ve tablo adı varsa, varsayılan SELECT ifadesini ekle
+	if gb.selectClause == "" && gb.tableClause != "" {
+		gb.selectClause = fmt.Sprintf("SELECT * FROM %s", gb.tableClause)
+	}
+}
+
+// Private method to AND a clause with the whole existing WHERE clause
+// The existing conditions are parenthesised when they contain OR, so the clause applies to all of them
+func (gb *GoBuilder) andWhole(clause string) {
+	existing := strings.TrimPrefix(gb.whereClause, "WHERE ")
+	if existing != "" && strings.Contains(strings.ToUpper(existing), " OR ") {
+		gb.whereClause = fmt.Sprintf("WHERE (%s)", existing)
+	}
+	gb.addWhere(clause)
+}
+
 // Private method to add IN clauses with values directly
 func (gb *GoBuilder) addInClause(OP, column string, args ...any) *GoBuilder {
 	if len(args) > 0 {
@@ -761,7 +922,7 @@ func (gb *GoBuilder) cleanValue(value any) string {
 }
 
 // sanitizeIdentifier sanitizes table and column names
-func (gb *GoBuilder) sanitizeIdentifier(identifier string) string {
+func sanitizeIdentifier(identifier string) string {
 	// Clean dangerous characters for SQL injection
 	parts := strings.Split(identifier, " as ")
 	if len(parts) > 2 {
@@ -867,6 +1028,27 @@ func (gb *GoBuilder) sanitizeIdentifier(identifier string) string {
 	return mainPart
 }
 
+// paramMarker returns the internal marker for the parameter at the given index
+func paramMarker(index int) string {
+	return fmt.Sprintf("\x00%d\x00", index)
+}
+
+// markerIndex returns the parameter index encoded in a marker
+func markerIndex(marker string) int {
+	index, _ := strconv.Atoi(strings.Trim(marker, "\x00"))
+	return index
+}
+
+// shiftMarkers moves every parameter marker in the fragment by offset
+func shiftMarkers(fragment string, offset int) string {
+	if offset == 0 {
+		return fragment
+	}
+	return markerPattern.ReplaceAllStringFunc(fragment, func(marker string) string {
+		return paramMarker(markerIndex(marker) + offset)
+	})
+}
+
 func (gb *GoBuilder) getPlaceholderCode() string {
 	switch gb.sqlDialect {
 	case Postgres:
@@ -904,9 +1086,7 @@ func (gb *GoBuilder) OnDuplicateKeyUpdate(args map[string]any) *GoBuilder {
 
 		setClauses := make([]string, 0, len(keys))
 		for _, key := range keys {
-			// MySQL için placeholder'ı doğrudan ? olarak kullan
-			setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
-			gb.paramsClause = append(gb.paramsClause, args[key])
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s", key, operand(gb, args[key])))
 		}
 
 		gb.selectClause += fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s", strings.Join(setClauses, ", "))
@@ -940,25 +1120,103 @@ func (gb *GoBuilder) Pragma(key string, value string) *GoBuilder {
 }
 
 // With adds WITH clause (CTE - Common Table Expression)
+// It can be called multiple times; the expressions are rendered in order in front of the
+// SELECT, INSERT, UPDATE or DELETE statement
 func (gb *GoBuilder) With(name string, subQuery *GoBuilder) *GoBuilder {
-	// Alt sorguyu hazırla
-	subQueryStr, subParams := subQuery.Prepare()
+	// Alt sorguyu hazırla ve parametrelerini ana sorguya ekle
+	gb.addCTE(name, nil, "", gb.embed(subQuery))
+	return gb
+}
 
-	// Alt sorgu parametrelerini ana sorguya ekle
-	gb.paramsClause = append(gb.paramsClause, subParams...)
+// WithColumns adds a CTE with an explicit column list
+// Example:
+//
+//	builder.WithColumns("totals", []string{"user_id", "total"}, subQuery)
+//	// Generates: WITH totals (user_id, total) AS (...)
+func (gb *GoBuilder) WithColumns(name string, columns []string, subQuery *GoBuilder) *GoBuilder {
+	gb.addCTE(name, columns, "", gb.embed(subQuery))
+	return gb
+}
 
-	// WITH clause'u oluştur
-	gb.selectClause = fmt.Sprintf("WITH %s AS (%s)", name, subQueryStr)
+// WithRecursive adds a recursive CTE made of an anchor query and a recursive query joined with UNION ALL
+// PostgreSQL, MySQL and SQLite render WITH RECURSIVE; SQL Server and Oracle use a plain WITH
+// Parameters:
+//   - name: The name of the CTE, referenced by the recursive query
+//   - columns: The column list of the CTE
+//   - anchor: The non-recursive starting query
+//   - recursive: The query that references the CTE itself
+//
+// Returns:
+//   - *GoBuilder: The builder instance for method chaining
+//
+// Example:
+//
+//	anchor := NewGoBuilder(Postgres).Table("employees").Select("id", "manager_id").Where("id", "=", 1)
+//	recursive := NewGoBuilder(Postgres).Table("employees as e").Select("e.id", "e.manager_id").Join("chain as c", "e.manager_id", "=", "c.id")
+//	builder.WithRecursive("chain", []string{"id", "manager_id"}, anchor, recursive).Table("chain").Select()
+//	// Generates: WITH RECURSIVE chain (id, manager_id) AS (SELECT id, manager_id FROM employees WHERE id = $1 UNION ALL SELECT e.id, e.manager_id FROM employees as e INNER JOIN chain as c ON e.manager_id = c.id) SELECT * FROM chain
+func (gb *GoBuilder) WithRecursive(name string, columns []string, anchor, recursive *GoBuilder) *GoBuilder {
+	if len(columns) == 0 {
+		gb.err = fmt.Errorf("recursive CTE %s requires a column list", name)
+		return gb
+	}
 
+	body := fmt.Sprintf("%s UNION ALL %s", gb.embed(anchor), gb.embed(recursive))
+	gb.addCTE(name, columns, "", body)
+	gb.recursiveCTE = true
 	return gb
 }
 
-// Lock adds FOR UPDATE/SHARE clause
-func (gb *GoBuilder) Lock(lockType string) *GoBuilder {
-	gb.selectClause = fmt.Sprintf("%s %s", gb.selectClause, lockType)
+// WithMaterialized adds a CTE with the MATERIALIZED hint (PostgreSQL and SQLite specific)
+func (gb *GoBuilder) WithMaterialized(name string, subQuery *GoBuilder) *GoBuilder {
+	return gb.withHint(name, "MATERIALIZED", subQuery)
+}
+
+// WithNotMaterialized adds a CTE with the NOT MATERIALIZED hint (PostgreSQL and SQLite specific)
+func (gb *GoBuilder) WithNotMaterialized(name string, subQuery *GoBuilder) *GoBuilder {
+	return gb.withHint(name, "NOT MATERIALIZED", subQuery)
+}
+
+// Private method to add CTEs with a materialization hint
+func (gb *GoBuilder) withHint(name, hint string, subQuery *GoBuilder) *GoBuilder {
+	if gb.sqlDialect != Postgres && gb.sqlDialect != SQLite {
+		gb.err = fmt.Errorf("%s is only supported in PostgreSQL and SQLite", hint)
+		return gb
+	}
+	gb.addCTE(name, nil, hint, gb.embed(subQuery))
 	return gb
 }
 
+// Private method to add a common table expression
+func (gb *GoBuilder) addCTE(name string, columns []string, hint, body string) {
+	cte := sanitizeIdentifier(name)
+	if len(columns) > 0 {
+		cleaned := make([]string, len(columns))
+		for i, column := range columns {
+			cleaned[i] = sanitizeIdentifier(column)
+		}
+		cte = fmt.Sprintf("%s (%s)", cte, strings.Join(cleaned, ", "))
+	}
+	if hint != "" {
+		cte = fmt.Sprintf("%s AS %s (%s)", cte, hint, body)
+	} else {
+		cte = fmt.Sprintf("%s AS (%s)", cte, body)
+	}
+	gb.cteClauses = append(gb.cteClauses, cte)
+}
+
+// Private method to render the WITH clause
+func (gb *GoBuilder) withClause() string {
+	if len(gb.cteClauses) == 0 {
+		return ""
+	}
+	keyword := "WITH"
+	if gb.recursiveCTE && gb.sqlDialect != SQLServer && gb.sqlDialect != Oracle {
+		keyword = "WITH RECURSIVE"
+	}
+	return fmt.Sprintf("%s %s", keyword, strings.Join(gb.cteClauses, ", "))
+}
+
 // WhenThen adds conditional clauses
 func (gb *GoBuilder) WhenThen(condition bool, trueCase, falseCase func(*GoBuilder) *GoBuilder) *GoBuilder {
 	if condition {
@@ -1011,6 +1269,182 @@ func (gb *GoBuilder) CreateBatch(records []map[string]any) *GoBuilder {
 	return gb
 }
 
+// UpdateBatch builds a single UPDATE statement that sets a different value for every record
+// Parameters:
+//   - keyColumn: The column that identifies each row (usually the primary key)
+//   - records: Rows to update, each containing keyColumn and the same set of columns
+//   - types: Optional PostgreSQL column types, such as {"id": "uuid", "meta": "jsonb"}; other columns are typed from their Go values
+//
+// Returns:
+//   - *GoBuilder: The builder instance for method chaining
+//
+// Example:
+//
+//	builder.Table("users").UpdateBatch("id", []map[string]any{
+//	    {"id": 1, "name": "John"},
+//	    {"id": 2, "name": "Jane"},
+//	})
+//	// PostgreSQL: UPDATE users SET name = v.name FROM (VALUES (CAST($1 AS BIGINT), CAST($2 AS TEXT)), ($3, $4)) AS v (id, name) WHERE users.id = v.id
+//	// MySQL/SQLite: UPDATE users SET name = CASE id WHEN ? THEN ? WHEN ? THEN ? ELSE name END WHERE id IN (?, ?)
+//	// SQL Server: UPDATE users SET name = v.name FROM users INNER JOIN (VALUES (@1, @2), (@3, @4)) AS v (id, name) ON users.id = v.id
+func (gb *GoBuilder) UpdateBatch(keyColumn string, records []map[string]any, types ...map[string]string) *GoBuilder {
+	if len(records) == 0 {
+		return gb
+	}
+
+	// Tip ipuçları CAST içine yazıldığı için doğrulanır
+	hints := make(map[string]string)
+	for _, columnTypes := range types {
+		for column, typeName := range columnTypes {
+			if !castTypePattern.MatchString(strings.TrimSpace(typeName)) {
+				gb.err = fmt.Errorf("invalid type name %q for column %s", typeName, column)
+				return gb
+			}
+			hints[column] = strings.TrimSpace(typeName)
+		}
+	}
+
+	// Sütun isimlerini ilk kayıttan al, anahtar sütunu hariç
+	columns := make([]string, 0, len(records[0]))
+	for key := range records[0] {
+		if key != keyColumn {
+			columns = append(columns, key)
+		}
+	}
+	sort.Strings(columns)
+
+	if len(columns) == 0 {
+		gb.err = fmt.Errorf("no columns to update besides %s", keyColumn)
+		return gb
+	}
+
+	// Tüm kayıtlar aynı sütunlara sahip olmalı
+	for i, record := range records {
+		if _, ok := record[keyColumn]; !ok {
+			gb.err = fmt.Errorf("record %d is missing key column %s", i, keyColumn)
+			return gb
+		}
+		if len(record) != len(columns)+1 {
+			gb.err = fmt.Errorf("record %d does not have the same columns as the first record", i)
+			return gb
+		}
+		for _, column := range columns {
+			if _, ok := record[column]; !ok {
+				gb.err = fmt.Errorf("record %d is missing column %s", i, column)
+				return gb
+			}
+		}
+	}
+
+	key := sanitizeIdentifier(keyColumn)
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = sanitizeIdentifier(column)
+	}
+
+	switch gb.sqlDialect {
+	case Postgres, SQLServer:
+		// Kolonları tablo adıyla değil, varsa alias ile nitele
+		target := outputName(gb.tableClause)
+		rows := make([]string, 0, len(records))
+		for i := range records {
+			values := []string{gb.batchValue(records, i, keyColumn, hints)}
+			for _, column := range columns {
+				values = append(values, gb.batchValue(records, i, column, hints))
+			}
+			rows = append(rows, fmt.Sprintf("(%s)", strings.Join(values, ", ")))
+		}
+
+		setClauses := make([]string, 0, len(names))
+		for _, name := range names {
+			setClauses = append(setClauses, fmt.Sprintf("%s = v.%s", name, name))
+		}
+
+		valuesTable := fmt.Sprintf(
+			"(VALUES %s) AS v (%s, %s)",
+			strings.Join(rows, ", "),
+			key,
+			strings.Join(names, ", "),
+		)
+		condition := fmt.Sprintf("%s.%s = v.%s", target, key, key)
+
+		if gb.sqlDialect == Postgres {
+			gb.selectClause = fmt.Sprintf("UPDATE %s SET %s FROM %s", gb.tableClause, strings.Join(setClauses, ", "), valuesTable)
+			gb.addClause("AND", condition)
+		} else {
+			gb.selectClause = fmt.Sprintf(
+				"UPDATE %s SET %s FROM %s INNER JOIN %s ON %s",
+				target,
+				strings.Join(setClauses, ", "),
+				gb.tableClause,
+				valuesTable,
+				condition,
+			)
+		}
+	default:
+		// MySQL, SQLite ve Oracle için CASE ifadesi kullan
+		setClauses := make([]string, 0, len(columns))
+		for i, column := range columns {
+			whens := make([]string, 0, len(records))
+			for _, record := range records {
+				whens = append(whens, fmt.Sprintf("WHEN %s THEN %s", gb.addParam(record[keyColumn]), gb.addParam(record[column])))
+			}
+			setClauses = append(setClauses, fmt.Sprintf("%s = CASE %s %s ELSE %s END", names[i], key, strings.Join(whens, " "), names[i]))
+		}
+		gb.selectClause = fmt.Sprintf("UPDATE %s SET %s", gb.tableClause, strings.Join(setClauses, ", "))
+
+		keys := make([]any, 0, len(records))
+		for _, record := range records {
+			keys = append(keys, record[keyColumn])
+		}
+		gb.addInClause("AND", key, keys...)
+	}
+
+	return gb
+}
+
+// batchValue binds a value of an UpdateBatch VALUES row
+// PostgreSQL types the columns of a VALUES list from its rows and treats untyped parameters as text,
+// so the first row casts each parameter to the hinted type of its column, or else to the type of
+// the first non-nil value of the column
+func (gb *GoBuilder) batchValue(records []map[string]any, row int, column string, hints map[string]string) string {
+	placeholder := gb.addParam(records[row][column])
+	if gb.sqlDialect != Postgres || row > 0 {
+		return placeholder
+	}
+	if hint, ok := hints[column]; ok {
+		return fmt.Sprintf("CAST(%s AS %s)", placeholder, hint)
+	}
+	for _, record := range records {
+		if sqlType := postgresType(record[column]); sqlType != "" {
+			return fmt.Sprintf("CAST(%s AS %s)", placeholder, sqlType)
+		}
+	}
+	return placeholder
+}
+
+// postgresType returns the PostgreSQL type of a bound Go value, or an empty string when it is unknown
+func postgresType(value any) string {
+	switch value.(type) {
+	case string:
+		return "TEXT"
+	case bool:
+		return "BOOLEAN"
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		return "BIGINT"
+	case uint, uint64:
+		return "NUMERIC"
+	case float32, float64:
+		return "DOUBLE PRECISION"
+	case time.Time:
+		return "TIMESTAMPTZ"
+	case []byte:
+		return "BYTEA"
+	default:
+		return ""
+	}
+}
+
 // Raw adds a raw SQL clause to the query with basic sanitization
 func (gb *GoBuilder) Raw(sql string, args ...any) *GoBuilder {
 	// SQL injection için temel kontroller
@@ -1237,23 +1671,92 @@ func (gb *GoBuilder) Raw(sql string, args ...any) *GoBuilder {
 	return gb
 }
 
-// Increment adds an increment operation to the query
-func (gb *GoBuilder) Increment(column string, amount int) *GoBuilder {
-	gb.selectClause = fmt.Sprintf("UPDATE %s SET %s = %s + %d", gb.tableClause, column, column, amount)
-	return gb
+// Increment adds an atomic "column = column + amount" assignment to an UPDATE statement
+// Parameters:
+//   - column: The column to increment
+//   - amount: Integer, float, decimal string or driver.Valuer, bound as a parameter
+//   - extra: Optional additional columns to set in the same statement
+//
+// Returns:
+//   - *GoBuilder: The builder instance for method chaining
+//
+// Example:
+//
+//	builder.Table("products").Increment("price", 1.5, map[string]any{"updated_at": now})
+//	// Generates: UPDATE products SET price = price + $1, updated_at = $2
+func (gb *GoBuilder) Increment(column string, amount any, extra ...map[string]any) *GoBuilder {
+	return gb.arithmetic(column, "+", amount, extra...)
+}
+
+// Decrement adds an atomic "column = column - amount" assignment to an UPDATE statement
+// It accepts the same arguments as Increment
+func (gb *GoBuilder) Decrement(column string, amount any, extra ...map[string]any) *GoBuilder {
+	return gb.arithmetic(column, "-", amount, extra...)
 }
 
-// Decrement adds a decrement operation to the query
-func (gb *GoBuilder) Decrement(column string, amount int) *GoBuilder {
-	gb.selectClause = fmt.Sprintf("UPDATE %s SET %s = %s - %d", gb.tableClause, column, column, amount)
+// Private method shared by Increment and Decrement
+func (gb *GoBuilder) arithmetic(column, operator string, amount any, extra ...map[string]any) *GoBuilder {
+	if !isNumeric(amount) {
+		gb.err = fmt.Errorf("invalid amount for %s: %v", column, amount)
+		return gb
+	}
+
+	setClauses := []string{fmt.Sprintf("%s = %s %s %s", column, column, operator, gb.addParam(amount))}
+	for _, args := range extra {
+		keys := make([]string, 0, len(args))
+		for key := range args {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s", key, operand(gb, args[key])))
+		}
+	}
+	gb.addSet(setClauses...)
 	return gb
 }
 
+// Private method to append SET assignments and rebuild the UPDATE statement
+// Update, Increment and Decrement share the same SET list so they can be combined
+// Assigning a column twice sets an error, since PostgreSQL rejects it and MySQL applies both in order
+func (gb *GoBuilder) addSet(clauses ...string) {
+	for _, clause := range clauses {
+		column, _, _ := strings.Cut(clause, " = ")
+		for _, existing := range gb.setClauses {
+			if assigned, _, _ := strings.Cut(existing, " = "); assigned == column {
+				gb.err = fmt.Errorf("column %s is assigned more than once", column)
+				return
+			}
+		}
+		gb.setClauses = append(gb.setClauses, clause)
+	}
+	gb.selectClause = fmt.Sprintf(
+		"UPDATE %s SET %s",
+		gb.tableClause,
+		strings.Join(gb.setClauses, ", "),
+	)
+	gb.checkLock()
+}
+
+// isNumeric reports whether the value can be used as an arithmetic operand
+func isNumeric(value any) bool {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	case string:
+		return decimalPattern.MatchString(v)
+	case driver.Valuer:
+		return true
+	default:
+		return false
+	}
+}
+
 // WhereExists adds a WHERE EXISTS clause
 func (gb *GoBuilder) WhereExists(subQuery *GoBuilder) *GoBuilder {
-	subQueryStr, subParams := subQuery.Prepare()
 	// Alt sorgu parametrelerini ana sorguya ekle
-	gb.paramsClause = append(gb.paramsClause, subParams...)
+	subQueryStr := gb.embed(subQuery)
 	clause := fmt.Sprintf("EXISTS (%s)", subQueryStr)
 	gb.addClause("AND", clause)
 	return gb
@@ -1261,9 +1764,8 @@ func (gb *GoBuilder) WhereExists(subQuery *GoBuilder) *GoBuilder {
 
 // WhereNotExists adds a WHERE NOT EXISTS clause
 func (gb *GoBuilder) WhereNotExists(subQuery *GoBuilder) *GoBuilder {
-	subQueryStr, subParams := subQuery.Prepare()
 	// Alt sorgu parametrelerini ana sorguya ekle
-	gb.paramsClause = append(gb.paramsClause, subParams...)
+	subQueryStr := gb.embed(subQuery)
 	clause := fmt.Sprintf("NOT EXISTS (%s)", subQueryStr)
 	gb.addClause("AND", clause)
 	return gb
@@ -1296,6 +1798,182 @@ func (gb *GoBuilder) FullOuterJoin(table, first, operator, last string) *GoBuild
 	return gb
 }
 
+// JoinOn adds an INNER JOIN whose ON part is built with the predicate API
+// Parameters:
+//   - table: The table to join
+//   - on: Callback that adds the join conditions
+//
+// Returns:
+//   - *GoBuilder: The builder instance for method chaining
+//
+// Example:
+//
+//	builder.Table("a").Select().JoinOn("b", func(j *JoinClause) {
+//	    j.On("a.id", "=", "b.a_id").IsNull("b.deleted_at").Where("b.kind", "=", "primary")
+//	})
+//	// Generates: SELECT * FROM a INNER JOIN b ON a.id = b.a_id AND b.deleted_at IS NULL AND b.kind = $1
+func (gb *GoBuilder) JoinOn(table string, on func(*JoinClause)) *GoBuilder {
+	return gb.joinOn("INNER JOIN", table, on)
+}
+
+// LeftJoinOn adds a LEFT JOIN whose ON part is built with the predicate API
+func (gb *GoBuilder) LeftJoinOn(table string, on func(*JoinClause)) *GoBuilder {
+	return gb.joinOn("LEFT JOIN", table, on)
+}
+
+// RightJoinOn adds a RIGHT JOIN whose ON part is built with the predicate API
+func (gb *GoBuilder) RightJoinOn(table string, on func(*JoinClause)) *GoBuilder {
+	return gb.joinOn("RIGHT JOIN", table, on)
+}
+
+// FullOuterJoinOn adds a FULL OUTER JOIN whose ON part is built with the predicate API
+func (gb *GoBuilder) FullOuterJoinOn(table string, on func(*JoinClause)) *GoBuilder {
+	return gb.joinOn("FULL OUTER JOIN", table, on)
+}
+
+// JoinUsing adds an INNER JOIN ... USING (columns) clause
+// Example:
+//
+//	builder.Table("orders").Select().JoinUsing("customers", "customer_id")
+//	// Generates: SELECT * FROM orders INNER JOIN customers USING (customer_id)
+func (gb *GoBuilder) JoinUsing(table string, columns ...string) *GoBuilder {
+	return gb.joinUsing("INNER JOIN", table, columns...)
+}
+
+// LeftJoinUsing adds a LEFT JOIN ... USING (columns) clause
+func (gb *GoBuilder) LeftJoinUsing(table string, columns ...string) *GoBuilder {
+	return gb.joinUsing("LEFT JOIN", table, columns...)
+}
+
+// NaturalJoin adds a NATURAL JOIN clause
+func (gb *GoBuilder) NaturalJoin(table string) *GoBuilder {
+	if gb.sqlDialect == SQLServer {
+		gb.err = fmt.Errorf("NATURAL JOIN is not supported in SQL Server")
+		return gb
+	}
+	gb.joinClauses = append(gb.joinClauses, fmt.Sprintf("NATURAL JOIN %s", table))
+	return gb
+}
+
+// JoinSub adds an INNER JOIN against a derived table built by another builder
+// The sub query parameters are merged into the main query
+// Example:
+//
+//	totals := NewGoBuilder(Postgres).Table("orders").Select("user_id", "SUM(amount) as total").Where("status", "=", "paid").GroupBy("user_id")
+//	builder.Table("users").Select("users.name", "t.total").JoinSub(totals, "t", "t.user_id", "=", "users.id")
+//	// Generates: SELECT users.name, t.total FROM users INNER JOIN (SELECT user_id, SUM(amount) as total FROM orders WHERE status = $1 GROUP BY user_id) AS t ON t.user_id = users.id
+func (gb *GoBuilder) JoinSub(subQuery *GoBuilder, alias, first, operator, last string) *GoBuilder {
+	join := fmt.Sprintf("INNER JOIN %s ON %s %s %s", gb.derivedTable(subQuery, alias), first, operator, last)
+	gb.joinClauses = append(gb.joinClauses, join)
+	return gb
+}
+
+// LeftJoinSub adds a LEFT JOIN against a derived table built by another builder
+func (gb *GoBuilder) LeftJoinSub(subQuery *GoBuilder, alias, first, operator, last string) *GoBuilder {
+	join := fmt.Sprintf("LEFT JOIN %s ON %s %s %s", gb.derivedTable(subQuery, alias), first, operator, last)
+	gb.joinClauses = append(gb.joinClauses, join)
+	return gb
+}
+
+// JoinLateral adds a lateral join, where the sub query can reference columns of the preceding tables
+// PostgreSQL and MySQL 8 use CROSS JOIN LATERAL, SQL Server and Oracle use CROSS APPLY
+// Example:
+//
+//	latest := NewGoBuilder(Postgres).Table("orders").Select("total").Where("orders.user_id", "=", "users.id").OrderByDesc("created_at").Limit(0, 1)
+//	builder.Table("users").Select("users.name", "o.total").JoinLateral(latest, "o")
+//	// Generates: SELECT users.name, o.total FROM users CROSS JOIN LATERAL (SELECT total FROM orders WHERE orders.user_id = users.id ORDER BY created_at DESC OFFSET 0 LIMIT 1) AS o
+func (gb *GoBuilder) JoinLateral(subQuery *GoBuilder, alias string) *GoBuilder {
+	switch gb.sqlDialect {
+	case Postgres, MySQL:
+		gb.joinClauses = append(gb.joinClauses, fmt.Sprintf("CROSS JOIN LATERAL %s", gb.derivedTable(subQuery, alias)))
+	case SQLServer, Oracle:
+		gb.joinClauses = append(gb.joinClauses, fmt.Sprintf("CROSS APPLY %s", gb.derivedTable(subQuery, alias)))
+	default:
+		gb.err = fmt.Errorf("LATERAL joins are not supported in %s", gb.sqlDialect)
+	}
+	return gb
+}
+
+// LeftJoinLateral adds a lateral join that keeps rows without a match
+// PostgreSQL and MySQL 8 use LEFT JOIN LATERAL ... ON TRUE, SQL Server and Oracle use OUTER APPLY
+func (gb *GoBuilder) LeftJoinLateral(subQuery *GoBuilder, alias string) *GoBuilder {
+	switch gb.sqlDialect {
+	case Postgres, MySQL:
+		gb.joinClauses = append(gb.joinClauses, fmt.Sprintf("LEFT JOIN LATERAL %s ON TRUE", gb.derivedTable(subQuery, alias)))
+	case SQLServer, Oracle:
+		gb.joinClauses = append(gb.joinClauses, fmt.Sprintf("OUTER APPLY %s", gb.derivedTable(subQuery, alias)))
+	default:
+		gb.err = fmt.Errorf("LATERAL joins are not supported in %s", gb.sqlDialect)
+	}
+	return gb
+}
+
+// CrossApply adds a CROSS APPLY clause (SQL Server and Oracle specific)
+func (gb *GoBuilder) CrossApply(subQuery *GoBuilder, alias string) *GoBuilder {
+	if gb.sqlDialect != SQLServer && gb.sqlDialect != Oracle {
+		gb.err = fmt.Errorf("CROSS APPLY is only supported in SQL Server and Oracle")
+		return gb
+	}
+	return gb.JoinLateral(subQuery, alias)
+}
+
+// OuterApply adds an OUTER APPLY clause (SQL Server and Oracle specific)
+func (gb *GoBuilder) OuterApply(subQuery *GoBuilder, alias string) *GoBuilder {
+	if gb.sqlDialect != SQLServer && gb.sqlDialect != Oracle {
+		gb.err = fmt.Errorf("OUTER APPLY is only supported in SQL Server and Oracle")
+		return gb
+	}
+	return gb.LeftJoinLateral(subQuery, alias)
+}
+
+// Private method to render a sub query as an aliased derived table
+// Oracle does not accept the AS keyword before table aliases
+func (gb *GoBuilder) derivedTable(subQuery *GoBuilder, alias string) string {
+	alias = sanitizeIdentifier(alias)
+	if gb.sqlDialect == Oracle {
+		return fmt.Sprintf("(%s) %s", gb.embed(subQuery), alias)
+	}
+	return fmt.Sprintf("(%s) AS %s", gb.embed(subQuery), alias)
+}
+
+// Private method to add joins built with JoinClause
+func (gb *GoBuilder) joinOn(joinType, table string, on func(*JoinClause)) *GoBuilder {
+	jc := &JoinClause{}
+	on(jc)
+	if jc.err != nil {
+		gb.err = jc.err
+		return gb
+	}
+	if jc.clause == "" {
+		gb.err = fmt.Errorf("%s %s requires at least one condition", joinType, table)
+		return gb
+	}
+
+	join := fmt.Sprintf("%s %s ON %s", joinType, table, gb.absorb(jc.clause, jc.params))
+	gb.joinClauses = append(gb.joinClauses, join)
+	return gb
+}
+
+// Private method to add USING joins
+func (gb *GoBuilder) joinUsing(joinType, table string, columns ...string) *GoBuilder {
+	if gb.sqlDialect == SQLServer {
+		gb.err = fmt.Errorf("JOIN ... USING is not supported in SQL Server")
+		return gb
+	}
+	if len(columns) == 0 {
+		gb.err = fmt.Errorf("%s %s USING requires at least one column", joinType, table)
+		return gb
+	}
+
+	cleaned := make([]string, len(columns))
+	for i, column := range columns {
+		cleaned[i] = sanitizeIdentifier(column)
+	}
+	join := fmt.Sprintf("%s %s USING (%s)", joinType, table, strings.Join(cleaned, ", "))
+	gb.joinClauses = append(gb.joinClauses, join)
+	return gb
+}
+
 // WhereColumn adds a WHERE column comparison
 func (gb *GoBuilder) WhereColumn(column1, operator, column2 string) *GoBuilder {
 	gb.addClause("AND", fmt.Sprintf("%s %s %s", column1, operator, column2))
@@ -1359,16 +2037,40 @@ func (gb *GoBuilder) Clone() *GoBuilder {
 		whereClause:   gb.whereClause,
 		groupByClause: gb.groupByClause,
 		havingClause:  gb.havingClause,
-		orderByClause: gb.orderByClause,
-		limitClause:   gb.limitClause,
+		orderTerms:    make([]orderTerm, len(gb.orderTerms)),
+		selectColumns: make([]string, len(gb.selectColumns)),
+		distinctOn:    make([]string, len(gb.distinctOn)),
+		limitValues:   gb.limitValues,
 		unionClause:   gb.unionClause,
 		joinClauses:   make([]string, len(gb.joinClauses)),
+		setClauses:    make([]string, len(gb.setClauses)),
+		cteClauses:    make([]string, len(gb.cteClauses)),
+		recursiveCTE:  gb.recursiveCTE,
+		windowClauses: make([]string, len(gb.windowClauses)),
+		lockClause:    gb.lockClause,
+		tableHints:    gb.tableHints,
+		returning:     gb.returning,
+		timeout:       gb.timeout,
 		paramsClause:  make([]any, len(gb.paramsClause)),
-		counterClause: gb.counterClause,
 		sqlDialect:    gb.sqlDialect,
 		holderCode:    gb.holderCode,
 	}
 	copy(clone.joinClauses, gb.joinClauses)
+	copy(clone.setClauses, gb.setClauses)
+	copy(clone.cteClauses, gb.cteClauses)
+	copy(clone.windowClauses, gb.windowClauses)
+	copy(clone.orderTerms, gb.orderTerms)
+	copy(clone.selectColumns, gb.selectColumns)
+	copy(clone.distinctOn, gb.distinctOn)
 	copy(clone.paramsClause, gb.paramsClause)
 	return clone
 }
+
+// stringsToAny converts column names for the methods that accept strings or expressions
+func stringsToAny(columns []string) []any {
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+	return values
+}
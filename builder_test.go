@@ -1,11 +1,44 @@
 package gobuilder
 
 import (
+	"fmt"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
+// sqlTestCase is a query built for a dialect with the SQL and parameters Prepare should return
+type sqlTestCase struct {
+	name     string
+	dialect  SQLDialect
+	builder  func(gb *GoBuilder) *GoBuilder
+	expected string
+	params   []any // The expected bindings, an empty slice when there are none
+}
+
+// runSQLTests runs every case as a subtest on a new builder of its dialect
+func runSQLTests(t *testing.T, tests []sqlTestCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gb := tt.builder(NewGoBuilder(tt.dialect))
+			if err := gb.Error(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			query, params := gb.Prepare()
+			if query != tt.expected {
+				t.Errorf("expected query %v, got %v", tt.expected, query)
+			}
+			if !reflect.DeepEqual(params, tt.params) {
+				t.Errorf("expected params %v, got %v", tt.params, params)
+			}
+		})
+	}
+}
+
 var (
 	query          string
 	params         []any
@@ -510,7 +543,7 @@ func TestSql_OrderBy(t *testing.T) {
 }
 
 func TestSql_OrderByMultiple(t *testing.T) {
-	queryExpected = "ORDER BY firstname, lastname ASC"
+	queryExpected = "ORDER BY firstname ASC, lastname ASC"
 	paramsExpected := []any{}
 	query, params = gb.OrderBy("firstname", "lastname").Prepare()
 	if !reflect.DeepEqual(queryExpected, query) {
@@ -534,7 +567,7 @@ func TestSql_OrderByDesc(t *testing.T) {
 }
 
 func TestSql_OrderByDescMultiple(t *testing.T) {
-	queryExpected = "ORDER BY firstname, lastname DESC"
+	queryExpected = "ORDER BY firstname DESC, lastname DESC"
 	paramsExpected := []any{}
 	query, params = gb.OrderByDesc("firstname", "lastname").Prepare()
 	if !reflect.DeepEqual(queryExpected, query) {
@@ -636,6 +669,112 @@ func TestSql_Union(t *testing.T) {
 	}
 }
 
+func TestSql_SetOperations(t *testing.T) {
+	customers := func(dialect SQLDialect) *GoBuilder {
+		return NewGoBuilder(dialect).Table("customers").Select("email").Where("active", "=", true)
+	}
+	subscribers := func(dialect SQLDialect) *GoBuilder {
+		return NewGoBuilder(dialect).Table("subscribers").Select("email").Where("confirmed", "=", true)
+	}
+
+	testCases := []struct {
+		name     string
+		dialect  SQLDialect
+		builder  func(gb *GoBuilder, dialect SQLDialect) (string, []any)
+		expected string
+		params   []any
+	}{
+		{
+			name:    "Intersect",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				return customers(dialect).Intersect(subscribers(dialect)).Prepare()
+			},
+			expected: "SELECT email FROM customers WHERE active = $1 INTERSECT SELECT email FROM subscribers WHERE confirmed = $2",
+			params:   []any{true, true},
+		},
+		{
+			name:    "Intersect All And Except All",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				return customers(dialect).IntersectAll(subscribers(dialect)).ExceptAll(NewGoBuilder(dialect).Table("bounced").Select("email")).Prepare()
+			},
+			expected: "SELECT email FROM customers WHERE active = $1 INTERSECT ALL SELECT email FROM subscribers WHERE confirmed = $2 EXCEPT ALL SELECT email FROM bounced",
+			params:   []any{true, true},
+		},
+		{
+			name:    "Except Is Minus On Oracle",
+			dialect: Oracle,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				return customers(dialect).Except(subscribers(dialect)).Prepare()
+			},
+			expected: "SELECT email FROM customers WHERE active = :1 MINUS SELECT email FROM subscribers WHERE confirmed = :2",
+			params:   []any{true, true},
+		},
+		{
+			name:    "Branch With Its Own Order And Limit",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				top := subscribers(dialect).OrderByDesc("created_at").Limit(0, 10)
+				return customers(dialect).Union(top).OrderBy("email").Limit(0, 50).Prepare()
+			},
+			expected: "SELECT email FROM customers WHERE active = ? UNION (SELECT email FROM subscribers WHERE confirmed = ? ORDER BY created_at DESC LIMIT 10 OFFSET 0) ORDER BY email ASC LIMIT 50 OFFSET 0",
+			params:   []any{true, true},
+		},
+		{
+			name:    "SQLite Branch Wrapped In Derived Table",
+			dialect: SQLite,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				top := subscribers(dialect).OrderByDesc("created_at").Limit(0, 10)
+				return customers(dialect).Except(top).Prepare()
+			},
+			expected: "SELECT email FROM customers WHERE active = ? EXCEPT SELECT * FROM (SELECT email FROM subscribers WHERE confirmed = ? ORDER BY created_at DESC LIMIT 10 OFFSET 0)",
+			params:   []any{true, true},
+		},
+		{
+			name:    "SQLServer Branch Wrapped In Derived Table",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				top := subscribers(dialect).OrderByDesc("created_at").Limit(0, 10)
+				return customers(dialect).Union(top).Prepare()
+			},
+			expected: "SELECT email FROM customers WHERE active = @1 UNION SELECT * FROM (SELECT email FROM subscribers WHERE confirmed = @2 ORDER BY created_at DESC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY) AS branch",
+			params:   []any{true, true},
+		},
+		{
+			name:    "Nested Set Operation",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				both := subscribers(dialect).Union(NewGoBuilder(dialect).Table("leads").Select("email"))
+				return customers(dialect).Except(both).Prepare()
+			},
+			expected: "SELECT email FROM customers WHERE active = $1 EXCEPT (SELECT email FROM subscribers WHERE confirmed = $2 UNION SELECT email FROM leads)",
+			params:   []any{true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, params := tc.builder(NewGoBuilder(tc.dialect), tc.dialect)
+			if query != tc.expected {
+				t.Errorf("expected query %v, got %v", tc.expected, query)
+			}
+			if !reflect.DeepEqual(params, tc.params) {
+				t.Errorf("expected params %v, got %v", tc.params, params)
+			}
+		})
+	}
+}
+
+func TestSql_SetOperationErrors(t *testing.T) {
+	for _, dialect := range []SQLDialect{SQLite, SQLServer} {
+		gb := NewGoBuilder(dialect).Table("a").Select().IntersectAll(NewGoBuilder(dialect).Table("b").Select())
+		if gb.Error() == nil {
+			t.Errorf("expected an error for INTERSECT ALL on %s", dialect)
+		}
+	}
+}
+
 func TestSql_SubQuery(t *testing.T) {
 	paramsExpected := []any{"SELECT id FROM users WHERE age > $1"}
 	mainBuilder := NewGoBuilder(Postgres)
@@ -761,6 +900,22 @@ func TestSql_AggregateFunctions(t *testing.T) {
 			expected: "SELECT customer_id, COUNT(*) as total_orders, SUM(amount) as total_amount, AVG(amount) as avg_amount, MIN(amount) as min_amount, MAX(amount) as max_amount FROM orders GROUP BY customer_id HAVING COUNT(*) > $1 ORDER BY total_amount ASC",
 			params:   []any{5},
 		},
+		{
+			name: "Aggregate Shortcuts",
+			builder: func() (string, []any) {
+				return gb.Table("orders").Select("COUNT AS total", "SUM amount AS revenue").Prepare()
+			},
+			expected: "SELECT COUNT(*) as total, SUM(amount) as revenue FROM orders",
+			params:   []any{},
+		},
+		{
+			name: "Column Names Containing Aggregate Names",
+			builder: func() (string, []any) {
+				return gb.Table("accounts").Select("accounts.id", "order_count", "summary", "min_price").Prepare()
+			},
+			expected: "SELECT accounts.id, order_count, summary, min_price FROM accounts",
+			params:   []any{},
+		},
 		{
 			name: "Aggregate with CASE",
 			builder: func() (string, []any) {
@@ -823,6 +978,130 @@ func TestSql_WithCTE(t *testing.T) {
 	}
 }
 
+func TestSql_MultipleCTEs(t *testing.T) {
+	recursiveTerms := func(dialect SQLDialect) (*GoBuilder, *GoBuilder) {
+		anchor := NewGoBuilder(dialect).Table("employees").Select("id", "manager_id").Where("id", "=", 1)
+		recursive := NewGoBuilder(dialect).
+			Table("employees as e").
+			Select("e.id", "e.manager_id").
+			Join("chain as c", "e.manager_id", "=", "c.id")
+		return anchor, recursive
+	}
+
+	testCases := []struct {
+		name     string
+		dialect  SQLDialect
+		builder  func(gb *GoBuilder, dialect SQLDialect) (string, []any)
+		expected string
+		params   []any
+	}{
+		{
+			name:    "Multiple CTEs",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				paid := NewGoBuilder(dialect).Table("orders").Select("user_id", "amount").Where("status", "=", "paid")
+				totals := NewGoBuilder(dialect).Table("paid").Select("user_id", "SUM(amount) as total").GroupBy("user_id")
+				return gb.With("paid", paid).
+					WithColumns("totals", []string{"user_id", "total"}, totals).
+					Table("totals").
+					Select().
+					Where("total", ">", 100).
+					Prepare()
+			},
+			expected: "WITH paid AS (SELECT user_id, amount FROM orders WHERE status = $1), totals (user_id, total) AS (SELECT user_id, SUM(amount) as total FROM paid GROUP BY user_id) SELECT * FROM totals WHERE total > $2",
+			params:   []any{"paid", 100},
+		},
+		{
+			name:    "Recursive CTE PostgreSQL",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				anchor, recursive := recursiveTerms(dialect)
+				return gb.WithRecursive("chain", []string{"id", "manager_id"}, anchor, recursive).Table("chain").Select().Prepare()
+			},
+			expected: "WITH RECURSIVE chain (id, manager_id) AS (SELECT id, manager_id FROM employees WHERE id = $1 UNION ALL SELECT e.id, e.manager_id FROM employees as e INNER JOIN chain as c ON e.manager_id = c.id) SELECT * FROM chain",
+			params:   []any{1},
+		},
+		{
+			name:    "Recursive CTE SQL Server",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				anchor, recursive := recursiveTerms(dialect)
+				return gb.WithRecursive("chain", []string{"id", "manager_id"}, anchor, recursive).Table("chain").Select().Prepare()
+			},
+			expected: "WITH chain (id, manager_id) AS (SELECT id, manager_id FROM employees WHERE id = @1 UNION ALL SELECT e.id, e.manager_id FROM employees as e INNER JOIN chain as c ON e.manager_id = c.id) SELECT * FROM chain",
+			params:   []any{1},
+		},
+		{
+			name:    "Materialized CTE",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				recent := NewGoBuilder(dialect).Table("events").Select("id").Where("day", ">", 7)
+				return gb.WithMaterialized("recent", recent).
+					WithNotMaterialized("all_events", NewGoBuilder(dialect).Table("events").Select("id")).
+					Table("recent").
+					Select().
+					Prepare()
+			},
+			expected: "WITH recent AS MATERIALIZED (SELECT id FROM events WHERE day > $1), all_events AS NOT MATERIALIZED (SELECT id FROM events) SELECT * FROM recent",
+			params:   []any{7},
+		},
+		{
+			name:    "CTE Before UPDATE",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				stale := NewGoBuilder(dialect).Table("sessions").Select("user_id").Where("age", ">", 30)
+				return gb.With("stale", stale).
+					Table("users").
+					Update(map[string]any{"active": false}).
+					Where("id", "IN", NewGoBuilder(dialect).Table("stale").Select("user_id")).
+					Prepare()
+			},
+			expected: "WITH stale AS (SELECT user_id FROM sessions WHERE age > ?) UPDATE users SET active = ? WHERE id IN (SELECT user_id FROM stale)",
+			params:   []any{30, false},
+		},
+		{
+			name:    "CTE Before DELETE",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				stale := NewGoBuilder(dialect).Table("sessions").Select("id").Where("age", ">", 30)
+				return gb.With("stale", stale).
+					Table("sessions").
+					Delete().
+					Where("id", "IN", NewGoBuilder(dialect).Table("stale").Select("id")).
+					Prepare()
+			},
+			expected: "WITH stale AS (SELECT id FROM sessions WHERE age > $1) DELETE FROM sessions WHERE id IN (SELECT id FROM stale)",
+			params:   []any{30},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, params := tc.builder(NewGoBuilder(tc.dialect), tc.dialect)
+			if query != tc.expected {
+				t.Errorf("expected query %v, got %v", tc.expected, query)
+			}
+			if !reflect.DeepEqual(params, tc.params) {
+				t.Errorf("expected params %v, got %v", tc.params, params)
+			}
+		})
+	}
+}
+
+func TestSql_CTEErrors(t *testing.T) {
+	gb := NewGoBuilder(MySQL)
+	gb.WithMaterialized("recent", NewGoBuilder(MySQL).Table("events").Select())
+	if gb.Error() == nil {
+		t.Error("expected an error for MATERIALIZED on MySQL")
+	}
+
+	gb = NewGoBuilder(Postgres)
+	gb.WithRecursive("chain", nil, NewGoBuilder(Postgres).Table("a").Select(), NewGoBuilder(Postgres).Table("b").Select())
+	if gb.Error() == nil {
+		t.Error("expected an error for a recursive CTE without columns")
+	}
+}
+
 func TestSql_BatchInsert(t *testing.T) {
 	records := []map[string]any{
 		{"name": "John", "age": 30},
@@ -842,6 +1121,145 @@ func TestSql_BatchInsert(t *testing.T) {
 	}
 }
 
+func TestSql_UpdateBatch(t *testing.T) {
+	records := []map[string]any{
+		{"id": 1, "name": "John", "age": 30},
+		{"id": 2, "name": "Jane", "age": 25},
+	}
+
+	testCases := []struct {
+		name     string
+		dialect  SQLDialect
+		expected string
+		params   []any
+	}{
+		{
+			name:     "PostgreSQL - VALUES",
+			dialect:  Postgres,
+			expected: "UPDATE users SET age = v.age, name = v.name FROM (VALUES (CAST($1 AS BIGINT), CAST($2 AS BIGINT), CAST($3 AS TEXT)), ($4, $5, $6)) AS v (id, age, name) WHERE users.id = v.id AND active = $7",
+			params:   []any{1, 30, "John", 2, 25, "Jane", true},
+		},
+		{
+			name:     "MySQL - CASE",
+			dialect:  MySQL,
+			expected: "UPDATE users SET age = CASE id WHEN ? THEN ? WHEN ? THEN ? ELSE age END, name = CASE id WHEN ? THEN ? WHEN ? THEN ? ELSE name END WHERE id IN (?, ?) AND active = ?",
+			params:   []any{1, 30, 2, 25, 1, "John", 2, "Jane", 1, 2, true},
+		},
+		{
+			name:     "SQLite - CASE",
+			dialect:  SQLite,
+			expected: "UPDATE users SET age = CASE id WHEN ? THEN ? WHEN ? THEN ? ELSE age END, name = CASE id WHEN ? THEN ? WHEN ? THEN ? ELSE name END WHERE id IN (?, ?) AND active = ?",
+			params:   []any{1, 30, 2, 25, 1, "John", 2, "Jane", 1, 2, true},
+		},
+		{
+			name:     "SQLServer - Joined VALUES",
+			dialect:  SQLServer,
+			expected: "UPDATE users SET age = v.age, name = v.name FROM users INNER JOIN (VALUES (@1, @2, @3), (@4, @5, @6)) AS v (id, age, name) ON users.id = v.id WHERE active = @7",
+			params:   []any{1, 30, "John", 2, 25, "Jane", true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gb := NewGoBuilder(tc.dialect)
+			query, params := gb.Table("users").UpdateBatch("id", records).Where("active", "=", true).Prepare()
+			if query != tc.expected {
+				t.Errorf("expected query %v, got %v", tc.expected, query)
+			}
+			if !reflect.DeepEqual(params, tc.params) {
+				t.Errorf("expected params %v, got %v", tc.params, params)
+			}
+		})
+	}
+}
+
+func TestSql_UpdateBatchAliasAndTypes(t *testing.T) {
+	testCases := []sqlTestCase{
+		{
+			name:    "PostgreSQL - Alias",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users as u").UpdateBatch("id", []map[string]any{{"id": 1, "name": "John"}, {"id": 2, "name": "Jane"}})
+			},
+			expected: "UPDATE users as u SET name = v.name FROM (VALUES (CAST($1 AS BIGINT), CAST($2 AS TEXT)), ($3, $4)) AS v (id, name) WHERE u.id = v.id",
+			params:   []any{1, "John", 2, "Jane"},
+		},
+		{
+			name:    "SQLServer - Alias",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users as u").UpdateBatch("id", []map[string]any{{"id": 1, "name": "John"}, {"id": 2, "name": "Jane"}})
+			},
+			expected: "UPDATE u SET name = v.name FROM users as u INNER JOIN (VALUES (@1, @2), (@3, @4)) AS v (id, name) ON u.id = v.id",
+			params:   []any{1, "John", 2, "Jane"},
+		},
+		{
+			name:    "PostgreSQL - Type From Later Row",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").UpdateBatch("id", []map[string]any{{"id": 1, "archived_at": nil}, {"id": 2, "archived_at": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}})
+			},
+			expected: "UPDATE users SET archived_at = v.archived_at FROM (VALUES (CAST($1 AS BIGINT), CAST($2 AS TIMESTAMPTZ)), ($3, $4)) AS v (id, archived_at) WHERE users.id = v.id",
+			params:   []any{1, nil, 2, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:    "PostgreSQL - UUID Key And Type Hints",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").UpdateBatch("id", []map[string]any{
+					{"id": "7f1c2a52-0c4e-4b8e-9d59-3f2d1e0b6a11", "meta": nil, "name": "John"},
+					{"id": "1b0d6f3e-5a2c-4e7f-8a91-c4d2e6f8a0b3", "meta": nil, "name": "Jane"},
+				}, map[string]string{"id": "uuid", "meta": "jsonb"})
+			},
+			expected: "UPDATE users SET meta = v.meta, name = v.name FROM (VALUES (CAST($1 AS uuid), CAST($2 AS jsonb), CAST($3 AS TEXT)), ($4, $5, $6)) AS v (id, meta, name) WHERE users.id = v.id",
+			params:   []any{"7f1c2a52-0c4e-4b8e-9d59-3f2d1e0b6a11", nil, "John", "1b0d6f3e-5a2c-4e7f-8a91-c4d2e6f8a0b3", nil, "Jane"},
+		},
+		{
+			name:    "SQLServer - Type Hints Ignored",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").UpdateBatch("id", []map[string]any{{"id": 1, "name": "John"}}, map[string]string{"id": "uuid"})
+			},
+			expected: "UPDATE users SET name = v.name FROM users INNER JOIN (VALUES (@1, @2)) AS v (id, name) ON users.id = v.id",
+			params:   []any{1, "John"},
+		},
+		{
+			name:    "MySQL - Sanitized Columns",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").UpdateBatch("id", []map[string]any{{"id": 1, "name = 'x'; --": "John"}})
+			},
+			expected: "UPDATE users SET invalid_identifier = CASE id WHEN ? THEN ? ELSE invalid_identifier END WHERE id IN (?)",
+			params:   []any{1, "John", 1},
+		},
+	}
+
+	runSQLTests(t, testCases)
+}
+
+func TestSql_UpdateBatchMismatchedColumns(t *testing.T) {
+	gb := NewGoBuilder(Postgres)
+	gb.Table("users").UpdateBatch("id", []map[string]any{
+		{"id": 1, "name": "John"},
+		{"id": 2, "age": 25},
+	})
+	if gb.Error() == nil {
+		t.Error("expected an error for records with different columns")
+	}
+
+	gb = NewGoBuilder(Postgres)
+	gb.Table("users").UpdateBatch("id", []map[string]any{{"name": "John"}})
+	if gb.Error() == nil {
+		t.Error("expected an error for a record without the key column")
+	}
+
+	gb = NewGoBuilder(Postgres)
+	gb.Table("users").UpdateBatch("id", []map[string]any{{"id": 1, "name": "John"}}, map[string]string{"id": "uuid); DROP TABLE users; --"})
+	if gb.Error() == nil {
+		t.Error("expected an error for an invalid type hint")
+	}
+}
+
 func TestSql_LockMechanism(t *testing.T) {
 	queryExpected := "SELECT * FROM users FOR UPDATE"
 	paramsExpected := []any{}
@@ -991,6 +1409,208 @@ func TestSql_ComplexJoins(t *testing.T) {
 	}
 }
 
+func TestSql_RichJoins(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dialect  SQLDialect
+		builder  func(gb *GoBuilder) (string, []any)
+		expected string
+		params   []any
+	}{
+		{
+			name:    "Join With Multiple Conditions",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) (string, []any) {
+				return gb.Table("users").
+					Select("users.id", "addresses.city").
+					JoinOn("addresses", func(j *JoinClause) {
+						j.On("users.id", "=", "addresses.account_id").
+							IsNull("addresses.deleted_at").
+							Where("addresses.kind", "=", "billing")
+					}).
+					Where("users.status", "=", "active").
+					Prepare()
+			},
+			expected: "SELECT users.id, addresses.city FROM users INNER JOIN addresses ON users.id = addresses.account_id AND addresses.deleted_at IS NULL AND addresses.kind = $1 WHERE users.status = $2",
+			params:   []any{"billing", "active"},
+		},
+		{
+			name:    "MySQL Join Parameters Follow SQL Order",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) (string, []any) {
+				return gb.Table("users").
+					Select().
+					Where("users.status", "=", "active").
+					LeftJoinOn("addresses", func(j *JoinClause) {
+						j.On("users.id", "=", "addresses.account_id").
+							OrWhere("addresses.kind", "=", "billing")
+					}).
+					Prepare()
+			},
+			expected: "SELECT * FROM users LEFT JOIN addresses ON users.id = addresses.account_id OR addresses.kind = ? WHERE users.status = ?",
+			params:   []any{"billing", "active"},
+		},
+		{
+			name:    "Join Using",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) (string, []any) {
+				return gb.Table("orders").Select().JoinUsing("customers", "customer_id").LeftJoinUsing("regions", "region_id", "country_id").Prepare()
+			},
+			expected: "SELECT * FROM orders INNER JOIN customers USING (customer_id) LEFT JOIN regions USING (region_id, country_id)",
+			params:   []any{},
+		},
+		{
+			name:    "Natural Join",
+			dialect: SQLite,
+			builder: func(gb *GoBuilder) (string, []any) {
+				return gb.Table("orders").Select().NaturalJoin("customers").Prepare()
+			},
+			expected: "SELECT * FROM orders NATURAL JOIN customers",
+			params:   []any{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, params := tc.builder(NewGoBuilder(tc.dialect))
+			if query != tc.expected {
+				t.Errorf("expected query %v, got %v", tc.expected, query)
+			}
+			if !reflect.DeepEqual(params, tc.params) {
+				t.Errorf("expected params %v, got %v", tc.params, params)
+			}
+		})
+	}
+}
+
+func TestSql_SubQueryJoins(t *testing.T) {
+	totals := func(dialect SQLDialect) *GoBuilder {
+		return NewGoBuilder(dialect).
+			Table("orders").
+			Select("user_id", "SUM(amount) as total").
+			Where("status", "=", "paid").
+			GroupBy("user_id")
+	}
+	latest := func(dialect SQLDialect) *GoBuilder {
+		return NewGoBuilder(dialect).
+			Table("orders").
+			Select("total").
+			Where("orders.user_id", "=", "users.id").
+			Where("total", ">", 10)
+	}
+
+	testCases := []struct {
+		name     string
+		dialect  SQLDialect
+		builder  func(gb *GoBuilder, dialect SQLDialect) (string, []any)
+		expected string
+		params   []any
+	}{
+		{
+			name:    "Join Sub Renumbers Parameters",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				return gb.Table("users").
+					Select("users.name", "t.total").
+					Where("users.active", "=", true).
+					JoinSub(totals(dialect), "t", "t.user_id", "=", "users.id").
+					Prepare()
+			},
+			expected: "SELECT users.name, t.total FROM users INNER JOIN (SELECT user_id, SUM(amount) as total FROM orders WHERE status = $1 GROUP BY user_id) AS t ON t.user_id = users.id WHERE users.active = $2",
+			params:   []any{"paid", true},
+		},
+		{
+			name:    "Left Join Sub MySQL",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				return gb.Table("users").
+					Select().
+					Where("users.active", "=", true).
+					LeftJoinSub(totals(dialect), "t", "t.user_id", "=", "users.id").
+					Prepare()
+			},
+			expected: "SELECT * FROM users LEFT JOIN (SELECT user_id, SUM(amount) as total FROM orders WHERE status = ? GROUP BY user_id) AS t ON t.user_id = users.id WHERE users.active = ?",
+			params:   []any{"paid", true},
+		},
+		{
+			name:    "Left Join Lateral PostgreSQL",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				return gb.Table("users").Select().LeftJoinLateral(latest(dialect), "o").Prepare()
+			},
+			expected: "SELECT * FROM users LEFT JOIN LATERAL (SELECT total FROM orders WHERE orders.user_id = users.id AND total > $1) AS o ON TRUE",
+			params:   []any{10},
+		},
+		{
+			name:    "Join Lateral MySQL",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				return gb.Table("users").Select().JoinLateral(latest(dialect), "o").Prepare()
+			},
+			expected: "SELECT * FROM users CROSS JOIN LATERAL (SELECT total FROM orders WHERE orders.user_id = users.id AND total > ?) AS o",
+			params:   []any{10},
+		},
+		{
+			name:    "Cross Apply SQL Server",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				return gb.Table("users").Select().Where("users.active", "=", 1).CrossApply(latest(dialect), "o").Prepare()
+			},
+			expected: "SELECT * FROM users CROSS APPLY (SELECT total FROM orders WHERE orders.user_id = users.id AND total > @1) AS o WHERE users.active = @2",
+			params:   []any{10, 1},
+		},
+		{
+			name:    "Outer Apply Oracle",
+			dialect: Oracle,
+			builder: func(gb *GoBuilder, dialect SQLDialect) (string, []any) {
+				return gb.Table("users").Select().LeftJoinLateral(latest(dialect), "o").Prepare()
+			},
+			expected: "SELECT * FROM users OUTER APPLY (SELECT total FROM orders WHERE orders.user_id = users.id AND total > :1) o",
+			params:   []any{10},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, params := tc.builder(NewGoBuilder(tc.dialect), tc.dialect)
+			if query != tc.expected {
+				t.Errorf("expected query %v, got %v", tc.expected, query)
+			}
+			if !reflect.DeepEqual(params, tc.params) {
+				t.Errorf("expected params %v, got %v", tc.params, params)
+			}
+		})
+	}
+}
+
+func TestSql_SubQueryJoinErrors(t *testing.T) {
+	gb := NewGoBuilder(SQLite)
+	gb.Table("users").Select().JoinLateral(NewGoBuilder(SQLite).Table("orders").Select(), "o")
+	if gb.Error() == nil {
+		t.Error("expected an error for LATERAL on SQLite")
+	}
+
+	gb = NewGoBuilder(Postgres)
+	gb.Table("users").Select().CrossApply(NewGoBuilder(Postgres).Table("orders").Select(), "o")
+	if gb.Error() == nil {
+		t.Error("expected an error for CROSS APPLY on PostgreSQL")
+	}
+}
+
+func TestSql_RichJoinErrors(t *testing.T) {
+	gb := NewGoBuilder(SQLServer)
+	gb.Table("orders").Select().JoinUsing("customers", "customer_id")
+	if gb.Error() == nil {
+		t.Error("expected an error for USING on SQL Server")
+	}
+
+	gb = NewGoBuilder(Postgres)
+	gb.Table("orders").Select().JoinOn("customers", func(j *JoinClause) {})
+	if gb.Error() == nil {
+		t.Error("expected an error for a join without conditions")
+	}
+}
+
 func TestSql_ComplexSubqueries(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -1013,6 +1633,23 @@ func TestSql_ComplexSubqueries(t *testing.T) {
 			expected: "SELECT name, email FROM customers WHERE id IN (SELECT customer_id FROM orders WHERE total > $1)",
 			params:   []any{1000},
 		},
+		{
+			name: "Subquery After Outer Parameters",
+			builder: func() (string, []any) {
+				subQuery := NewGoBuilder(Postgres).
+					Table("orders").
+					Select("customer_id").
+					Where("total", ">", 1000)
+				return gb.Table("customers").
+					Select("name").
+					Where("status", "=", "active").
+					Where("id", "IN", subQuery).
+					Where("country", "=", "TR").
+					Prepare()
+			},
+			expected: "SELECT name FROM customers WHERE status = $1 AND id IN (SELECT customer_id FROM orders WHERE total > $2) AND country = $3",
+			params:   []any{"active", 1000, "TR"},
+		},
 		{
 			name: "Subquery in FROM",
 			builder: func() (string, []any) {
@@ -1029,6 +1666,37 @@ func TestSql_ComplexSubqueries(t *testing.T) {
 			expected: "SELECT customer_id, order_count FROM (SELECT customer_id, COUNT(*) as order_count FROM orders GROUP BY customer_id HAVING COUNT(*) > 5) as order_stats ORDER BY order_count ASC",
 			params:   []any{},
 		},
+		{
+			name: "Derived Table With Parameters",
+			builder: func() (string, []any) {
+				subQuery := NewGoBuilder(Postgres).
+					Table("orders").
+					Select("customer_id", "COUNT(*) as order_count").
+					Where("status", "=", "paid").
+					GroupBy("customer_id").
+					Having("COUNT(*) > ?", 5)
+				return gb.FromSub(subQuery, "order_stats").
+					Select("customer_id", "order_count").
+					Where("order_count", "<", 100).
+					OrderBy("order_count").
+					Prepare()
+			},
+			expected: "SELECT customer_id, order_count FROM (SELECT customer_id, COUNT(*) as order_count FROM orders WHERE status = $1 GROUP BY customer_id HAVING COUNT(*) > $2) AS order_stats WHERE order_count < $3 ORDER BY order_count ASC",
+			params:   []any{"paid", 5, 100},
+		},
+		{
+			name: "Derived Table Keeps Column Names",
+			builder: func() (string, []any) {
+				subQuery := NewGoBuilder(Postgres).
+					Table("orders").
+					Select("orders.customer_id", "total")
+				return gb.FromSub(subQuery, "o").
+					Select("o.customer_id", "o.total").
+					Prepare()
+			},
+			expected: "SELECT o.customer_id, o.total FROM (SELECT orders.customer_id, total FROM orders) AS o",
+			params:   []any{},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1246,16 +1914,63 @@ func TestSql_IncrementDecrement(t *testing.T) {
 			builder: func() (string, []any) {
 				return gb.Table("users").Increment("votes", 1).Prepare()
 			},
-			expected: "UPDATE users SET votes = votes + 1",
-			params:   []any{},
+			expected: "UPDATE users SET votes = votes + $1",
+			params:   []any{1},
 		},
 		{
 			name: "Decrement",
 			builder: func() (string, []any) {
 				return gb.Table("products").Decrement("stock", 5).Prepare()
 			},
-			expected: "UPDATE products SET stock = stock - 5",
-			params:   []any{},
+			expected: "UPDATE products SET stock = stock - $1",
+			params:   []any{5},
+		},
+		{
+			name: "Increment Float",
+			builder: func() (string, []any) {
+				return gb.Table("accounts").Increment("balance", 12.75).Where("id", "=", 7).Prepare()
+			},
+			expected: "UPDATE accounts SET balance = balance + $1 WHERE id = $2",
+			params:   []any{12.75, 7},
+		},
+		{
+			name: "Decrement Decimal String",
+			builder: func() (string, []any) {
+				return gb.Table("accounts").Decrement("balance", "0.10").Prepare()
+			},
+			expected: "UPDATE accounts SET balance = balance - $1",
+			params:   []any{"0.10"},
+		},
+		{
+			name: "Increment With Extra Columns",
+			builder: func() (string, []any) {
+				return gb.Table("posts").
+					Increment("views", 1, map[string]any{"updated_at": "2024-01-01", "last_viewer": 3}).
+					Where("id", "=", 10).
+					Prepare()
+			},
+			expected: "UPDATE posts SET views = views + $1, last_viewer = $2, updated_at = $3 WHERE id = $4",
+			params:   []any{1, 3, "2024-01-01", 10},
+		},
+		{
+			name: "Update Combined With Increment",
+			builder: func() (string, []any) {
+				return gb.Table("products").
+					Update(map[string]any{"status": "sold"}).
+					Decrement("stock", 1).
+					Where("id", "=", 3).
+					Prepare()
+			},
+			expected: "UPDATE products SET status = $1, stock = stock - $2 WHERE id = $3",
+			params:   []any{"sold", 1, 3},
+		},
+		{
+			name: "MySQL Increment",
+			builder: func() (string, []any) {
+				return NewGoBuilder(MySQL).Table("users").Increment("score", 2.5).Prepare()
+			},
+			expected: "UPDATE users SET score = score + ?",
+			params:   []any{2.5},
 		},
 	}
 
@@ -1272,6 +1987,41 @@ func TestSql_IncrementDecrement(t *testing.T) {
 	}
 }
 
+func TestSql_IncrementInvalidAmount(t *testing.T) {
+	gb := NewGoBuilder(Postgres)
+	gb.Table("users").Increment("votes", "1; DROP TABLE users")
+	if gb.Error() == nil {
+		t.Error("expected an error for a non-numeric amount")
+	}
+}
+
+func TestSql_DuplicateSetColumn(t *testing.T) {
+	testCases := map[string]func(gb *GoBuilder) *GoBuilder{
+		"Update Twice": func(gb *GoBuilder) *GoBuilder {
+			return gb.Table("users").Update(map[string]any{"a": 1}).Update(map[string]any{"a": 2})
+		},
+		"Update Then Increment": func(gb *GoBuilder) *GoBuilder {
+			return gb.Table("users").Update(map[string]any{"a": 1}).Increment("a", 1)
+		},
+		"Increment Extra Column": func(gb *GoBuilder) *GoBuilder {
+			return gb.Table("users").Increment("a", 1, map[string]any{"a": 5})
+		},
+	}
+
+	for name, build := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if build(NewGoBuilder(Postgres)).Error() == nil {
+				t.Error("expected an error for a column assigned twice")
+			}
+		})
+	}
+
+	query, params := NewGoBuilder(Postgres).Table("users").Update(map[string]any{"a": 1}).Increment("b", 1).Prepare()
+	if query != "UPDATE users SET a = $1, b = b + $2" || !reflect.DeepEqual(params, []any{1, 1}) {
+		t.Errorf("expected different columns to combine, got %v %v", query, params)
+	}
+}
+
 func TestSql_SQLInjectionPrevention(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -1438,3 +2188,232 @@ func BenchmarkSql_Delete(b *testing.B) {
 		gb.Table("users").Delete().Where("id", "=", i).Sql()
 	}
 }
+
+func TestSql_LimitDialects(t *testing.T) {
+	expected := map[SQLDialect]string{
+		Postgres:  "SELECT * FROM users ORDER BY id ASC OFFSET 20 LIMIT 10",
+		MySQL:     "SELECT * FROM users ORDER BY id ASC LIMIT 10 OFFSET 20",
+		SQLite:    "SELECT * FROM users ORDER BY id ASC LIMIT 10 OFFSET 20",
+		SQLServer: "SELECT * FROM users ORDER BY id ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		Oracle:    "SELECT * FROM users ORDER BY id ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+	}
+
+	for dialect, want := range expected {
+		t.Run(string(dialect), func(t *testing.T) {
+			query, _ := NewGoBuilder(dialect).Table("users").Select().OrderBy("id").Limit(20, 10).Prepare()
+			if query != want {
+				t.Errorf("expected query %v, got %v", want, query)
+			}
+		})
+	}
+
+	query, _ := NewGoBuilder(SQLServer).Table("users").Select().Limit(0, 5).Prepare()
+	if want := "SELECT * FROM users ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 5 ROWS ONLY"; query != want {
+		t.Errorf("expected query %v, got %v", want, query)
+	}
+}
+
+func TestSql_MatchesPrepare(t *testing.T) {
+	dialects := []SQLDialect{Postgres, MySQL, SQLite, SQLServer, Oracle}
+	sub := func(d SQLDialect) *GoBuilder {
+		return NewGoBuilder(d).Table("orders").Select("user_id").Where("total", ">", 100)
+	}
+
+	builders := map[string]func(d SQLDialect) *GoBuilder{
+		"Select": func(d SQLDialect) *GoBuilder { return NewGoBuilder(d).Table("users").Select() },
+		"SelectColumns": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select("id", "COUNT(*) as total")
+		},
+		"SelectDistinct": func(d SQLDialect) *GoBuilder { return NewGoBuilder(d).Table("users").SelectDistinct("country") },
+		"Create": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Create(map[string]any{"name": "John", "age": 30}, "id")
+		},
+		"CreateBatch": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").CreateBatch([]map[string]any{{"name": "John"}, {"name": "Jane"}})
+		},
+		"Update": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Update(map[string]any{"name": "John"}).Where("id", "=", 1)
+		},
+		"UpdateBatch": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").UpdateBatch("id", []map[string]any{{"id": 1, "name": "John"}, {"id": 2, "name": "Jane"}}).Where("active", "=", true)
+		},
+		"IncrementDecrement": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("products").Increment("views", 1, map[string]any{"seen": true}).Decrement("stock", 2.5).Where("id", "=", 3)
+		},
+		"Delete": func(d SQLDialect) *GoBuilder { return NewGoBuilder(d).Table("users").Delete().Where("id", "=", 1) },
+		"WherePredicates": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select().
+				Where("name", "=", "O'Reilly").OrWhere("age", ">", 30).
+				In("role", "admin", "editor").OrIn("team", 1, 2).
+				Between("score", 1, 10).OrBetween("rank", 5, 6).
+				IsNull("deleted_at").OrIsNull("banned_at").IsNotNull("email").OrIsNotNull("phone").
+				WhereColumn("created_at", "<", "updated_at")
+		},
+		"WhereSubQuery": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select().Where("active", "=", true).Where("id", "IN", sub(d))
+		},
+		"WhereExists": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select().WhereExists(sub(d)).WhereNotExists(sub(d))
+		},
+		"WhereDates": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("orders").Select().
+				WhereDate("created_at", "=", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)).
+				WhereYear("created_at", "=", 2024).
+				WhereMonth("created_at", "=", 1)
+		},
+		"Joins": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select().
+				Join("roles", "roles.id", "=", "users.role_id").
+				LeftJoin("teams", "teams.id", "=", "users.team_id").
+				RightJoin("offices", "offices.id", "=", "users.office_id").
+				FullOuterJoin("desks", "desks.id", "=", "users.desk_id").
+				CrossJoin("settings")
+		},
+		"JoinOn": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select().Where("users.active", "=", true).
+				JoinOn("addresses", func(j *JoinClause) {
+					j.On("addresses.user_id", "=", "users.id").Where("addresses.kind", "=", "billing")
+				})
+		},
+		"JoinSub": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select().Where("users.active", "=", true).
+				JoinSub(sub(d), "o", "o.user_id", "=", "users.id").
+				LeftJoinSub(sub(d), "p", "p.user_id", "=", "users.id")
+		},
+		"JoinLateral": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select().Where("users.active", "=", true).
+				JoinLateral(sub(d), "o").LeftJoinLateral(sub(d), "p")
+		},
+		"FromSub": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).FromSub(sub(d), "o").Select().Where("o.user_id", ">", 5)
+		},
+		"GroupHaving": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("orders").Select("user_id", "SUM(total) as total").
+				GroupBy("user_id").Having("SUM(total) > ?", 100)
+		},
+		"Expressions": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("orders").
+				SelectExpr(Col("user_id"), Sum(Col("total").Mul(Lit(2))).As("doubled"), Coalesce(Col("note"), "none")).
+				Where("status", "=", "paid").
+				GroupByExpr(Col("user_id"), Col("note")).
+				Having(Count().Gt(3))
+		},
+		"Windows": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("orders").
+				SelectExpr(Col("id"), Window(Sum(Col("total")).Filter(Cond().Where("status", "=", "paid"))).PartitionBy(Col("user_id")).As("paid_total")).
+				Where("total", ">", 10)
+		},
+		"CaseUpdate": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("accounts").
+				Update(map[string]any{"level": Case().When(Cond().Where("points", ">=", 500), "vip").Else("basic")}).
+				Where("region", "=", "eu")
+		},
+		"Rollup": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("sales").SelectExpr("region", "city", Sum(Col("amount")).As("total")).
+				Where("year", "=", 2024).GroupByRollup("region", "city").Having("SUM(amount) > ?", 10).OrHaving(Count().Gt(2))
+		},
+		"OrderTerms": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("tasks").Select().Where("team", "=", "core").
+				OrderByExpr(Asc(Coalesce(Col("due_at"), Col("created_at"))).NullsLast(), Desc(Func("ABS", Col("score").Sub(50))))
+		},
+		"DistinctOn": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("orders").DistinctOn("user_id").Select("user_id", "id", "created_at").
+				Where("status", "=", "paid").OrderByExpr("user_id", Desc("created_at")).Limit(0, 10)
+		},
+		"TypedLock": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("jobs").Select().Where("status", "=", "queued").OrderBy("id").Limit(0, 5).
+				Lock(ForUpdate().SkipLocked())
+		},
+		"Timeout": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("reports").Select("id").Where("year", "=", 2024).WithTimeout(2 * time.Second)
+		},
+		"OrderLimit": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select().Where("age", ">", 18).OrderBy("name").OrderByDesc("age").Limit(10, 5)
+		},
+		"SetOperations": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select("email").Where("active", "=", true).
+				Union(sub(d)).UnionAll(sub(d)).Intersect(sub(d)).Except(sub(d).OrderBy("user_id").Limit(0, 5)).
+				OrderBy("email").Limit(0, 20)
+		},
+		"CTEs": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).With("big", sub(d)).
+				WithRecursive("chain", []string{"id"}, sub(d), NewGoBuilder(d).Table("chain").Select("id").Where("id", "<", 10)).
+				Table("big").Select().Where("user_id", "=", 1)
+		},
+		"Tree": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Descendants("categories", "id", "parent_id", 1).Where("depth", "<", 3)
+		},
+		"Raw": func(d SQLDialect) *GoBuilder { return NewGoBuilder(d).Raw("SELECT * FROM users WHERE id = ?", 1) },
+		"Lock": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select().Where("id", "=", 1).Lock("FOR UPDATE")
+		},
+		"WhenThen": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select().WhenThen(true, func(b *GoBuilder) *GoBuilder { return b.Where("id", "=", 1) }, nil)
+		},
+		"JsonContains": func(d SQLDialect) *GoBuilder {
+			return NewGoBuilder(d).Table("users").Select().WhereJsonContains("prefs", `{"theme": "dark"}`)
+		},
+		"DialectSpecific": func(d SQLDialect) *GoBuilder {
+			switch d {
+			case MySQL:
+				return NewGoBuilder(d).Table("users").Create(map[string]any{"id": 1}).OnDuplicateKeyUpdate(map[string]any{"name": "John"})
+			case SQLServer:
+				return NewGoBuilder(d).Table("users").Select().Where("id", ">", 1).Top(5)
+			case SQLite:
+				return NewGoBuilder(d).Pragma("foreign_keys", "ON")
+			case Oracle:
+				return NewGoBuilder(d).DescendantsConnectBy("categories", "id", "parent_id", 1)
+			default:
+				return NewGoBuilder(d).Table("users").Select().WhereJsonContains("prefs", "{}")
+			}
+		},
+	}
+
+	for name, build := range builders {
+		for _, dialect := range dialects {
+			t.Run(fmt.Sprintf("%s/%s", name, dialect), func(t *testing.T) {
+				gb := build(dialect)
+				if gb.Error() != nil {
+					t.Skipf("not supported: %v", gb.Error())
+				}
+				sql := gb.Sql()
+				query, params := build(dialect).Prepare()
+
+				inlined := inlineParams(t, query, params, dialect)
+				if sql != inlined {
+					t.Errorf("Sql() and Prepare() differ\nSql:     %s\nPrepare: %s", sql, inlined)
+				}
+			})
+		}
+	}
+}
+
+// inlineParams replaces the placeholders of a prepared query with the inlined parameter values
+// and checks that numbered placeholders follow the order of the parameters
+func inlineParams(t *testing.T, query string, params []any, dialect SQLDialect) string {
+	t.Helper()
+	patterns := map[SQLDialect]string{Postgres: `\$(\d+)`, SQLServer: `@(\d+)`, Oracle: `:(\d+)`}
+	pattern, ok := patterns[dialect]
+	if !ok {
+		pattern = `\?`
+	}
+
+	gb := NewGoBuilder(dialect)
+	i := 0
+	re := regexp.MustCompile(pattern)
+	inlined := re.ReplaceAllStringFunc(query, func(placeholder string) string {
+		if m := re.FindStringSubmatch(placeholder); len(m) == 2 && m[1] != strconv.Itoa(i+1) {
+			t.Errorf("placeholder %s found at position %d", placeholder, i+1)
+		}
+		if i >= len(params) {
+			t.Fatalf("more placeholders than parameters in %s", query)
+		}
+		value := gb.cleanValue(params[i])
+		i++
+		return value
+	})
+	if i != len(params) {
+		t.Errorf("expected %d placeholders, found %d in %s", len(params), i, query)
+	}
+	return inlined
+}
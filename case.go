@@ -0,0 +1,115 @@
+package gobuilder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CaseExpr is a CASE expression built with Case
+// Conditions reuse the Condition predicate API and every value is bound as a parameter
+type CaseExpr struct {
+	operand Expr       // The operand of a simple CASE, nil for a searched CASE
+	whens   []caseWhen // The WHEN ... THEN ... branches
+	elseVal any        // The ELSE value
+	hasElse bool       // Whether Else was called, since nil is a valid ELSE value
+	alias   string     // The alias used when the expression is selected
+}
+
+// caseWhen is a single WHEN ... THEN ... branch
+type caseWhen struct {
+	when   any
+	result any
+}
+
+// Case starts a CASE expression
+// Without an operand it is a searched CASE whose When conditions are *Condition values or expressions;
+// with an operand it is a simple CASE whose When values are compared with the operand
+// Example:
+//
+//	Case().When(Cond().Where("total", ">", 1000), "gold").Else("standard").As("tier")
+//	// Generates: CASE WHEN total > $1 THEN $2 ELSE $3 END as tier
+//
+//	Case(Col("status")).When("a", 1).When("b", 2).Else(0)
+//	// Generates: CASE status WHEN $1 THEN $2 WHEN $3 THEN $4 ELSE $5 END
+func Case(operand ...Expr) *CaseExpr {
+	c := &CaseExpr{}
+	if len(operand) > 0 {
+		c.operand = operand[0]
+	}
+	return c
+}
+
+// When returns a copy of the expression with a WHEN ... THEN ... branch added
+func (c *CaseExpr) When(when, result any) *CaseExpr {
+	copied := c.clone()
+	copied.whens = append(copied.whens, caseWhen{when: when, result: result})
+	return copied
+}
+
+// Else returns a copy of the expression with the ELSE value set
+func (c *CaseExpr) Else(value any) *CaseExpr {
+	copied := c.clone()
+	copied.elseVal = value
+	copied.hasElse = true
+	return copied
+}
+
+// As returns a copy of the expression with the alias used when it appears in a SELECT list
+func (c *CaseExpr) As(alias string) *CaseExpr {
+	copied := c.clone()
+	copied.alias = sanitizeIdentifier(alias)
+	return copied
+}
+
+// Private method to copy the expression, so a base CASE can be extended in several ways
+// The branches are clipped, so appending to the copy never writes into the receiver's array
+func (c *CaseExpr) clone() *CaseExpr {
+	copied := *c
+	copied.whens = slices.Clip(c.whens)
+	return &copied
+}
+
+func (c *CaseExpr) aliasName() string {
+	return c.alias
+}
+
+func (c *CaseExpr) toSQL(gb *GoBuilder) string {
+	if len(c.whens) == 0 {
+		gb.err = fmt.Errorf("CASE requires at least one WHEN branch")
+		return "NULL"
+	}
+
+	parts := []string{"CASE"}
+	if c.operand != nil {
+		parts = append(parts, c.operand.toSQL(gb))
+	}
+	for _, w := range c.whens {
+		parts = append(parts, "WHEN", c.condition(gb, w.when), "THEN", operand(gb, w.result))
+	}
+	if c.hasElse {
+		parts = append(parts, "ELSE", operand(gb, c.elseVal))
+	}
+	parts = append(parts, "END")
+	return strings.Join(parts, " ")
+}
+
+// Private method to render a WHEN condition
+func (c *CaseExpr) condition(gb *GoBuilder, when any) string {
+	if cond, ok := when.(*Condition); ok {
+		if c.operand != nil {
+			gb.err = fmt.Errorf("simple CASE compares values, use Case() without an operand for conditions")
+		}
+		if cond.err != nil {
+			gb.err = cond.err
+		}
+		if cond.clause == "" {
+			gb.err = fmt.Errorf("CASE WHEN requires a condition")
+		}
+		return gb.absorb(cond.clause, cond.params)
+	}
+	if _, ok := when.(Expr); !ok && c.operand == nil {
+		gb.err = fmt.Errorf("searched CASE requires a *Condition or an expression, got %T", when)
+	}
+	return operand(gb, when)
+}
@@ -0,0 +1,110 @@
+package gobuilder
+
+import (
+	"testing"
+)
+
+func TestCase(t *testing.T) {
+	tests := []sqlTestCase{
+		{
+			name:    "Searched Case In Select",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").
+					SelectExpr(Col("id"), Case().
+						When(Cond().Where("total", ">", 1000), "gold").
+						When(Cond().Between("total", 100, 1000).IsNull("refunded_at"), "silver").
+						Else("standard").As("tier")).
+					Where("year", "=", 2024)
+			},
+			expected: "SELECT id, CASE WHEN total > $1 THEN $2 WHEN total BETWEEN $3 AND $4 AND refunded_at IS NULL THEN $5 ELSE $6 END as tier FROM orders WHERE year = $7",
+			params:   []any{1000, "gold", 100, 1000, "silver", "standard", 2024},
+		},
+		{
+			name:    "Simple Case",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("tickets").SelectExpr(Case(Col("status")).When("open", 1).When("closed", 2).Else(0).As("status_code"))
+			},
+			expected: "SELECT CASE status WHEN ? THEN ? WHEN ? THEN ? ELSE ? END as status_code FROM tickets",
+			params:   []any{"open", 1, "closed", 2, 0},
+		},
+		{
+			name:    "Case With Expression Conditions And Results",
+			dialect: SQLite,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("items").SelectExpr(Case().When(Col("stock").Lte(0), Col("backorder_price")).Else(Col("price")).As("price"))
+			},
+			expected: "SELECT CASE WHEN stock <= ? THEN backorder_price ELSE price END as price FROM items",
+			params:   []any{0},
+		},
+		{
+			name:    "Case In Order By",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("tickets").Select().Where("team", "=", "core").
+					OrderByExpr(Case(Col("priority")).When("urgent", 0).When("high", 1).Else(2))
+			},
+			expected: "SELECT * FROM tickets WHERE team = $1 ORDER BY CASE priority WHEN $2 THEN $3 WHEN $4 THEN $5 ELSE $6 END ASC",
+			params:   []any{"core", "urgent", 0, "high", 1, 2},
+		},
+		{
+			name:    "Case In Update Set",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("accounts").
+					Update(map[string]any{
+						"level":  Case().When(Cond().Where("points", ">=", 500), "vip").Else(Col("level")),
+						"status": "checked",
+					}).
+					Where("region", "=", "eu")
+			},
+			expected: "UPDATE accounts SET level = CASE WHEN points >= @1 THEN @2 ELSE level END, status = @3 WHERE region = @4",
+			params:   []any{500, "vip", "checked", "eu"},
+		},
+		{
+			name:    "Case Without Else",
+			dialect: Oracle,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").SelectExpr(Case().When(Cond().IsNotNull("deleted_at"), "deleted").As("state"))
+			},
+			expected: "SELECT CASE WHEN deleted_at IS NOT NULL THEN :1 END as state FROM users",
+			params:   []any{"deleted"},
+		},
+		{
+			name:    "Reused Base Case",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				base := Case(Col("status")).When("open", 1).When("closed", 2).When("held", 3)
+				return gb.Table("tickets").SelectExpr(base, base.Else(0).As("foo"), base.When("new", 4).As("bar"))
+			},
+			expected: "SELECT CASE status WHEN $1 THEN $2 WHEN $3 THEN $4 WHEN $5 THEN $6 END, " +
+				"CASE status WHEN $7 THEN $8 WHEN $9 THEN $10 WHEN $11 THEN $12 ELSE $13 END as foo, " +
+				"CASE status WHEN $14 THEN $15 WHEN $16 THEN $17 WHEN $18 THEN $19 WHEN $20 THEN $21 END as bar FROM tickets",
+			params: []any{"open", 1, "closed", 2, "held", 3, "open", 1, "closed", 2, "held", 3, 0,
+				"open", 1, "closed", 2, "held", 3, "new", 4},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestCase_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr Expr
+	}{
+		{"No Branches", Case().Else(1)},
+		{"Searched Case With Plain Value", Case().When("active", 1)},
+		{"Simple Case With Condition", Case(Col("status")).When(Cond().Where("a", "=", 1), 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gb := NewGoBuilder(Postgres).Table("users").SelectExpr(tt.expr)
+			if gb.Error() == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package gobuilder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ClaimOptions configures ClaimBatch; at least one of ClaimedAtColumn, AttemptsColumn and Set must mark claimed rows
+type ClaimOptions struct {
+	KeyColumn         string         // The primary key column, "id" by default
+	ClaimedAtColumn   string         // Set to the claim time; only unclaimed rows, or rows claimed before the visibility timeout, are claimed
+	VisibilityTimeout time.Duration  // How long a claimed row stays invisible to other workers, 0 to never claim it again
+	AttemptsColumn    string         // Incremented on every claim
+	MaxAttempts       int            // Rows claimed this many times are skipped, 0 for no limit
+	Set               map[string]any // Additional assignments, such as a status column
+}
+
+// txBeginner is implemented by executors that can start a transaction, such as *sql.DB and *sql.Conn
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// ClaimBatch claims up to n rows of a job queue table for the calling worker and returns them
+// Rows locked by other workers are skipped with FOR UPDATE SKIP LOCKED, so concurrent workers never
+// claim the same row. PostgreSQL runs a single UPDATE ... RETURNING *; MySQL 8 selects and locks the keys,
+// updates and reads the rows inside a transaction, started on ex when it can begin one.
+// Other dialects return an error. Like Prepare, it resets the builder
+// Parameters:
+//   - ctx: Context for the statements
+//   - ex: Executor such as *sql.DB or *sql.Tx
+//   - table: The queue table
+//   - n: The maximum number of rows to claim
+//   - opts: Key, visibility timeout and retry counter columns
+//   - where: Conditions the claimed rows must match
+//
+// Returns:
+//   - []map[string]any: The claimed rows, after the update
+//   - error: Any build or database error
+//
+// Example:
+//
+//	jobs, err := builder.ClaimBatch(ctx, db, "jobs", 10, ClaimOptions{
+//	    ClaimedAtColumn: "claimed_at", VisibilityTimeout: 5 * time.Minute, AttemptsColumn: "attempts", MaxAttempts: 3,
+//	}, Cond().Where("queue", "=", "mail"))
+//	// Runs: UPDATE jobs SET attempts = (attempts + $1), claimed_at = CURRENT_TIMESTAMP WHERE id IN (SELECT id FROM jobs
+//	//   WHERE (queue = $2) AND (claimed_at IS NULL OR claimed_at < CURRENT_TIMESTAMP - $3 * INTERVAL '1 microsecond')
+//	//   AND attempts < $4 ORDER BY id ASC OFFSET 0 LIMIT 10 FOR UPDATE SKIP LOCKED) RETURNING *
+func (gb *GoBuilder) ClaimBatch(ctx context.Context, ex Executor, table string, n int, opts ClaimOptions, where ...*Condition) ([]map[string]any, error) {
+	defer gb.reset()
+
+	if gb.err != nil {
+		return nil, gb.err
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("invalid batch size %d", n)
+	}
+	if opts.ClaimedAtColumn == "" && opts.AttemptsColumn == "" && len(opts.Set) == 0 {
+		return nil, fmt.Errorf("ClaimBatch requires ClaimedAtColumn, AttemptsColumn or Set to mark claimed rows")
+	}
+	if opts.KeyColumn == "" {
+		opts.KeyColumn = "id"
+	}
+	ctx, cancel := gb.timeoutContext(ctx)
+	defer cancel()
+
+	switch gb.sqlDialect {
+	case Postgres:
+		update := gb.claimUpdate(table, opts).Where(opts.KeyColumn, "IN", gb.claimKeys(table, n, opts, where))
+		update.returning = "RETURNING *"
+		return update.Query(ctx, ex)
+	case MySQL:
+		keys := gb.claimKeys(table, n, opts, where)
+		if keys.err != nil {
+			return nil, keys.err
+		}
+		if db, ok := ex.(txBeginner); ok {
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+			claimed, err := gb.claimInTx(ctx, tx, table, opts, keys)
+			if err != nil {
+				_ = tx.Rollback()
+				return nil, err
+			}
+			return claimed, tx.Commit()
+		}
+		return gb.claimInTx(ctx, ex, table, opts, keys)
+	default:
+		return nil, fmt.Errorf("ClaimBatch is not supported in %s", gb.sqlDialect)
+	}
+}
+
+// Private method to run the MySQL claim steps; ex must be a transaction so the key locks are held until the update
+func (gb *GoBuilder) claimInTx(ctx context.Context, ex Executor, table string, opts ClaimOptions, keys *GoBuilder) ([]map[string]any, error) {
+	rows, err := keys.Query(ctx, ex)
+	if err != nil || len(rows) == 0 {
+		return rows, err
+	}
+
+	ids := make([]any, len(rows))
+	for i, row := range rows {
+		ids[i] = row[opts.KeyColumn]
+	}
+	if _, err := gb.claimUpdate(table, opts).In(opts.KeyColumn, ids...).Exec(ctx, ex); err != nil {
+		return nil, err
+	}
+	claimed := NewGoBuilder(gb.sqlDialect).Table(table).Select().In(opts.KeyColumn, ids...).OrderBy(opts.KeyColumn)
+	claimed.timeout = gb.timeout
+	return claimed.Query(ctx, ex)
+}
+
+// Private method to build the locking subquery selecting the keys of the rows to claim
+func (gb *GoBuilder) claimKeys(table string, n int, opts ClaimOptions, where []*Condition) *GoBuilder {
+	keys := NewGoBuilder(gb.sqlDialect).Table(table).Select(opts.KeyColumn)
+	keys.timeout = gb.timeout
+	for _, condition := range where {
+		if condition.err != nil {
+			keys.err = condition.err
+			return keys
+		}
+		// Each condition is grouped, so OR terms do not escape it
+		keys.addClause("AND", fmt.Sprintf("(%s)", keys.absorb(condition.clause, condition.params)))
+	}
+
+	if opts.ClaimedAtColumn != "" {
+		if opts.VisibilityTimeout > 0 {
+			keys.addClause("AND", fmt.Sprintf("(%s IS NULL OR %s < %s)",
+				opts.ClaimedAtColumn, opts.ClaimedAtColumn, keys.claimClock(opts.VisibilityTimeout)))
+		} else {
+			keys.IsNull(opts.ClaimedAtColumn)
+		}
+	}
+	if opts.AttemptsColumn != "" && opts.MaxAttempts > 0 {
+		keys.Where(opts.AttemptsColumn, "<", opts.MaxAttempts)
+	}
+	return keys.OrderBy(opts.KeyColumn).Limit(0, n).Lock(ForUpdate().SkipLocked())
+}
+
+// Private method to build the UPDATE marking rows as claimed, without its WHERE clause
+func (gb *GoBuilder) claimUpdate(table string, opts ClaimOptions) *GoBuilder {
+	set := make(map[string]any, len(opts.Set)+2)
+	for column, value := range opts.Set {
+		set[column] = value
+	}
+	if opts.ClaimedAtColumn != "" {
+		set[opts.ClaimedAtColumn] = &Expression{render: func(gb *GoBuilder) string { return gb.claimClock(0) }}
+	}
+	if opts.AttemptsColumn != "" {
+		set[opts.AttemptsColumn] = Col(opts.AttemptsColumn).Add(1)
+	}
+	update := NewGoBuilder(gb.sqlDialect).Table(table).Update(set)
+	update.timeout = gb.timeout
+	return update
+}
+
+// Private method to render the database time, less the given duration
+// The database clock is used so workers with skewed clocks agree on claim times
+func (gb *GoBuilder) claimClock(before time.Duration) string {
+	if before <= 0 {
+		return "CURRENT_TIMESTAMP"
+	}
+	micros := gb.addParam(before.Microseconds())
+	if gb.sqlDialect == MySQL {
+		return fmt.Sprintf("CURRENT_TIMESTAMP - INTERVAL %s MICROSECOND", micros)
+	}
+	return fmt.Sprintf("CURRENT_TIMESTAMP - %s * INTERVAL '1 microsecond'", micros)
+}
@@ -0,0 +1,150 @@
+package gobuilder
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClaimBatch_Postgres(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) fakeResponse {
+		return fakeResponse{
+			columns: []string{"id", "queue", "attempts"},
+			rows:    [][]driver.Value{{int64(3), "mail", int64(1)}, {int64(4), "mail", int64(2)}},
+		}
+	})
+	defer db.Close()
+
+	jobs, err := NewGoBuilder(Postgres).ClaimBatch(context.Background(), db, "jobs", 10, ClaimOptions{
+		ClaimedAtColumn:   "claimed_at",
+		VisibilityTimeout: 5 * time.Minute,
+		AttemptsColumn:    "attempts",
+		MaxAttempts:       3,
+		Set:               map[string]any{"status": "running"},
+	}, Cond().Where("queue", "=", "mail").OrWhere("queue", "=", "sms"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"UPDATE jobs SET attempts = (attempts + $1), claimed_at = CURRENT_TIMESTAMP, status = $2 WHERE id IN " +
+		"(SELECT id FROM jobs WHERE (queue = $3 OR queue = $4) AND (claimed_at IS NULL OR claimed_at < CURRENT_TIMESTAMP - $5 * INTERVAL '1 microsecond') " +
+		"AND attempts < $6 ORDER BY id ASC OFFSET 0 LIMIT 10 FOR UPDATE SKIP LOCKED) RETURNING *"}
+	if !reflect.DeepEqual(fake.queries(), expected) {
+		t.Errorf("expected queries %v, got %v", expected, fake.queries())
+	}
+
+	expectedArgs := []any{int64(1), "running", "mail", "sms", (5 * time.Minute).Microseconds(), int64(3)}
+	if !reflect.DeepEqual(fake.args(0), expectedArgs) {
+		t.Errorf("expected params %v, got %v", expectedArgs, fake.args(0))
+	}
+	if len(jobs) != 2 || jobs[1]["id"] != int64(4) {
+		t.Errorf("unexpected claimed rows %v", jobs)
+	}
+}
+
+func TestClaimBatch_PostgresRunsThroughQuery(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) fakeResponse {
+		if strings.HasPrefix(query, "UPDATE") {
+			return fakeResponse{err: &pgError{code: "23505"}}
+		}
+		return fakeResponse{}
+	})
+	defer db.Close()
+
+	ctx := context.Background()
+	err := Transact(ctx, db, &TxOptions{Dialect: Postgres}, func(tx Executor) error {
+		_, err := NewGoBuilder(Postgres).WithTimeout(2*time.Second).ClaimBatch(ctx, tx, "jobs", 1, ClaimOptions{AttemptsColumn: "attempts"})
+		return err
+	})
+	if !errors.Is(err, ErrUniqueViolation) {
+		t.Errorf("expected a translated unique violation, got %v", err)
+	}
+	if queries := fake.queries(); len(queries) < 2 || queries[1] != "SET LOCAL statement_timeout = 2000" {
+		t.Errorf("expected the statement timeout before the claim, got %v", queries)
+	}
+}
+
+func TestClaimBatch_MySQL(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) fakeResponse {
+		switch {
+		case strings.HasPrefix(query, "SELECT id FROM"):
+			return fakeResponse{columns: []string{"id"}, rows: [][]driver.Value{{int64(3)}, {int64(4)}}}
+		case strings.HasPrefix(query, "SELECT *"):
+			return fakeResponse{columns: []string{"id", "status"}, rows: [][]driver.Value{{int64(3), "running"}, {int64(4), "running"}}}
+		}
+		return fakeResponse{rowsAffected: 2}
+	})
+	defer db.Close()
+
+	jobs, err := NewGoBuilder(MySQL).ClaimBatch(context.Background(), db, "jobs", 2, ClaimOptions{
+		ClaimedAtColumn: "claimed_at",
+		Set:             map[string]any{"status": "running"},
+	}, Cond().Where("status", "=", "queued"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"BEGIN",
+		"SELECT id FROM jobs WHERE (status = ?) AND claimed_at IS NULL ORDER BY id ASC LIMIT 2 OFFSET 0 FOR UPDATE SKIP LOCKED",
+		"UPDATE jobs SET claimed_at = CURRENT_TIMESTAMP, status = ? WHERE id IN (?, ?)",
+		"SELECT * FROM jobs WHERE id IN (?, ?) ORDER BY id ASC",
+		"COMMIT",
+	}
+	if !reflect.DeepEqual(fake.queries(), expected) {
+		t.Errorf("expected queries %v, got %v", expected, fake.queries())
+	}
+	if !reflect.DeepEqual(fake.args(2), []any{"running", int64(3), int64(4)}) {
+		t.Errorf("unexpected update params %v", fake.args(2))
+	}
+	if len(jobs) != 2 || jobs[0]["status"] != "running" {
+		t.Errorf("unexpected claimed rows %v", jobs)
+	}
+}
+
+func TestClaimBatch_MySQLEmptyQueue(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) fakeResponse {
+		return fakeResponse{columns: []string{"id"}}
+	})
+	defer db.Close()
+
+	jobs, err := NewGoBuilder(MySQL).ClaimBatch(context.Background(), db, "jobs", 5, ClaimOptions{AttemptsColumn: "attempts"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 0 {
+		t.Errorf("expected no rows, got %v", jobs)
+	}
+	expected := []string{"BEGIN", "SELECT id FROM jobs ORDER BY id ASC LIMIT 5 OFFSET 0 FOR UPDATE SKIP LOCKED", "COMMIT"}
+	if !reflect.DeepEqual(fake.queries(), expected) {
+		t.Errorf("expected queries %v, got %v", expected, fake.queries())
+	}
+}
+
+func TestClaimBatch_Errors(t *testing.T) {
+	db, _ := newFakeDB(nil)
+	defer db.Close()
+
+	tests := []struct {
+		name    string
+		dialect SQLDialect
+		n       int
+		opts    ClaimOptions
+	}{
+		{"Unsupported Dialect", SQLite, 1, ClaimOptions{AttemptsColumn: "attempts"}},
+		{"Invalid Batch Size", Postgres, 0, ClaimOptions{AttemptsColumn: "attempts"}},
+		{"Nothing To Update", Postgres, 1, ClaimOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGoBuilder(tt.dialect).ClaimBatch(context.Background(), db, "jobs", tt.n, tt.opts); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
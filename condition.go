@@ -0,0 +1,154 @@
+package gobuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Condition collects predicates combined with AND / OR
+// It offers the same predicate methods as GoBuilder's WHERE API and is used wherever
+// a standalone condition is needed, such as the ON part of a join built with JoinOn
+//
+// Example:
+//
+//	Cond().On("b.a_id", "=", "a.id").IsNull("b.deleted_at").Where("b.kind", "=", "primary")
+//	// Generates: b.a_id = a.id AND b.deleted_at IS NULL AND b.kind = $1
+type Condition struct {
+	clause string // The predicates collected so far
+	params []any  // Parameters referenced by the predicates
+	err    error  // Stores any errors that occur while building the condition
+}
+
+// JoinClause describes the ON conditions of a join built with JoinOn
+type JoinClause struct {
+	Condition
+}
+
+// Cond creates an empty condition
+func Cond() *Condition {
+	return &Condition{}
+}
+
+// On adds a column to column comparison, such as "b.a_id = a.id"
+func (c *Condition) On(first, operator, second string) *Condition {
+	c.add("AND", fmt.Sprintf("%s %s %s", sanitizeIdentifier(first), operator, sanitizeIdentifier(second)))
+	return c
+}
+
+// OrOn adds an OR column to column comparison
+func (c *Condition) OrOn(first, operator, second string) *Condition {
+	c.add("OR", fmt.Sprintf("%s %s %s", sanitizeIdentifier(first), operator, sanitizeIdentifier(second)))
+	return c
+}
+
+// Where adds a comparison against a bound value or a sub query
+// Unlike GoBuilder.Where, string values are always bound as parameters; use On to compare columns
+func (c *Condition) Where(key, opt string, val any) *Condition {
+	c.add("AND", c.comparison(key, opt, val))
+	return c
+}
+
+// OrWhere adds an OR comparison against a bound value or a sub query
+func (c *Condition) OrWhere(key, opt string, val any) *Condition {
+	c.add("OR", c.comparison(key, opt, val))
+	return c
+}
+
+// In adds an IN clause with bind parameters
+func (c *Condition) In(column string, args ...any) *Condition {
+	return c.in("AND", column, args...)
+}
+
+// OrIn adds an OR IN clause with bind parameters
+func (c *Condition) OrIn(column string, args ...any) *Condition {
+	return c.in("OR", column, args...)
+}
+
+// Between adds a BETWEEN clause with bind parameters
+func (c *Condition) Between(column string, args ...any) *Condition {
+	return c.between("AND", column, args...)
+}
+
+// OrBetween adds an OR BETWEEN clause with bind parameters
+func (c *Condition) OrBetween(column string, args ...any) *Condition {
+	return c.between("OR", column, args...)
+}
+
+// IsNull adds an IS NULL clause
+func (c *Condition) IsNull(column string) *Condition {
+	c.add("AND", fmt.Sprintf("%s IS NULL", column))
+	return c
+}
+
+// OrIsNull adds an OR IS NULL clause
+func (c *Condition) OrIsNull(column string) *Condition {
+	c.add("OR", fmt.Sprintf("%s IS NULL", column))
+	return c
+}
+
+// IsNotNull adds an IS NOT NULL clause
+func (c *Condition) IsNotNull(column string) *Condition {
+	c.add("AND", fmt.Sprintf("%s IS NOT NULL", column))
+	return c
+}
+
+// OrIsNotNull adds an OR IS NOT NULL clause
+func (c *Condition) OrIsNotNull(column string) *Condition {
+	c.add("OR", fmt.Sprintf("%s IS NOT NULL", column))
+	return c
+}
+
+// Error returns the first error that occurred while building the condition
+func (c *Condition) Error() error {
+	return c.err
+}
+
+// Private method to build a comparison clause
+func (c *Condition) comparison(key, opt string, val any) string {
+	key = sanitizeIdentifier(key)
+	if sub, ok := val.(*GoBuilder); ok {
+		if sub.err != nil && c.err == nil {
+			c.err = sub.err
+		}
+		query, params := sub.detach()
+		query = shiftMarkers(query, len(c.params))
+		c.params = append(c.params, params...)
+		return fmt.Sprintf("%s %s (%s)", key, opt, query)
+	}
+	return fmt.Sprintf("%s %s %s", key, opt, c.addParam(val))
+}
+
+// Private method to add IN clauses
+func (c *Condition) in(OP, column string, args ...any) *Condition {
+	if len(args) > 0 {
+		values := make([]string, len(args))
+		for i, arg := range args {
+			values[i] = c.addParam(arg)
+		}
+		c.add(OP, fmt.Sprintf("%s IN (%s)", column, strings.Join(values, ", ")))
+	}
+	return c
+}
+
+// Private method to add BETWEEN clauses
+func (c *Condition) between(OP, column string, args ...any) *Condition {
+	if len(args) == 2 {
+		c.add(OP, fmt.Sprintf("%s BETWEEN %s AND %s", column, c.addParam(args[0]), c.addParam(args[1])))
+	}
+	return c
+}
+
+// Private method to add parameters
+func (c *Condition) addParam(value any) string {
+	c.params = append(c.params, value)
+	return paramMarker(len(c.params) - 1)
+}
+
+// Private method to add clauses with logical operators
+func (c *Condition) add(OP, clause string) {
+	if c.clause != "" {
+		c.clause = fmt.Sprintf("%s %s %s", c.clause, OP, clause)
+	} else {
+		c.clause = clause
+	}
+}
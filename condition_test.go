@@ -0,0 +1,33 @@
+package gobuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCondition(t *testing.T) {
+	sub := NewGoBuilder(Postgres).Table("blocked").Select("user_id").Where("reason", "=", "spam")
+	cond := Cond().
+		On("a.id", "=", "b.a_id").
+		Where("b.kind", "=", "primary").
+		In("b.state", "new", "open").
+		OrBetween("b.score", 1, 5).
+		Where("b.user_id", "NOT IN", sub)
+
+	query, params := NewGoBuilder(Postgres).
+		Table("a").
+		Select().
+		Where("a.region", "=", "eu").
+		JoinOn("b", func(j *JoinClause) { j.Condition = *cond }).
+		Prepare()
+
+	queryExpected := "SELECT * FROM a INNER JOIN b ON a.id = b.a_id AND b.kind = $1 AND b.state IN ($2, $3) OR b.score BETWEEN $4 AND $5 AND b.user_id NOT IN (SELECT user_id FROM blocked WHERE reason = $6) WHERE a.region = $7"
+	paramsExpected := []any{"primary", "new", "open", 1, 5, "spam", "eu"}
+
+	if !reflect.DeepEqual(queryExpected, query) {
+		t.Errorf("queryExpected = %v, query %v", queryExpected, query)
+	}
+	if !reflect.DeepEqual(paramsExpected, params) {
+		t.Errorf("paramsExpected = %v, params %v", paramsExpected, params)
+	}
+}
@@ -0,0 +1,196 @@
+package gobuilder
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Sentinel errors for constraint violations, matched with errors.Is on errors returned by
+// Exec, Query and TranslateError
+var (
+	ErrUniqueViolation     = errors.New("unique constraint violation")
+	ErrForeignKeyViolation = errors.New("foreign key constraint violation")
+	ErrNotNullViolation    = errors.New("not null constraint violation")
+	ErrCheckViolation      = errors.New("check constraint violation")
+)
+
+// ErrNoRows is returned when a query expected to return a row returned none
+// It is sql.ErrNoRows, so errors.Is works with either
+var ErrNoRows = sql.ErrNoRows
+
+// DBError is a driver error translated by TranslateError
+// errors.Is matches both Kind and the driver error, and errors.As still finds the driver error type
+type DBError struct {
+	Kind       error  // One of ErrUniqueViolation, ErrForeignKeyViolation, ErrNotNullViolation or ErrCheckViolation
+	Constraint string // The violated constraint or index, when the driver reports it
+	Table      string // The table, when the driver reports it
+	Column     string // The column, when the driver reports it
+	Err        error  // The driver error
+}
+
+// Error returns the kind of violation followed by the driver message
+func (e *DBError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+}
+
+// Unwrap returns the kind of violation and the driver error
+func (e *DBError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// Message patterns naming the constraint, table or column of a violation, per dialect
+var (
+	pgConstraintPattern     = regexp.MustCompile(`constraint "([^"]+)"`)
+	pgColumnPattern         = regexp.MustCompile(`column "([^"]+)"`)
+	mysqlKeyPattern         = regexp.MustCompile("for key '([^']+)'")
+	mysqlConstraintPattern  = regexp.MustCompile("(?:CONSTRAINT `|Check constraint ')([^`']+)")
+	mysqlColumnPattern      = regexp.MustCompile("(?:Column|Field) '([^']+)'")
+	sqlServerNamePattern    = regexp.MustCompile(`(?:constraint|index) ["']([^"']+)["']`)
+	sqlServerTablePattern   = regexp.MustCompile(`(?:object|table) '([^']+)'`)
+	sqlServerColumnPattern  = regexp.MustCompile(`column '([^']+)'`)
+	sqliteColumnsPattern    = regexp.MustCompile(`constraint failed: ([\w.]+)`)
+	oracleCodePattern       = regexp.MustCompile(`ORA-(\d{5})`)
+	oracleConstraintPattern = regexp.MustCompile(`\(([\w$#]+\.[\w$#]+)\)`)
+	oracleColumnPattern     = regexp.MustCompile(`\("[^"]+"\."([^"]+)"\."([^"]+)"\)`)
+)
+
+// TranslateError wraps constraint violations of the dialect in a *DBError, and returns other errors as they are
+// Drivers are recognised by SQLSTATE or error number, read like IsRetryable does, and by their messages
+// Example:
+//
+//	_, err := builder.Table("users").Create(map[string]any{"email": email}).Exec(ctx, db)
+//	var dbErr *DBError
+//	if errors.Is(err, ErrUniqueViolation) && errors.As(err, &dbErr) {
+//	    log.Printf("%s is taken (%s)", email, dbErr.Constraint)
+//	}
+func TranslateError(dialect SQLDialect, err error) error {
+	if err == nil || errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	var translated *DBError
+	if errors.As(err, &translated) {
+		return err
+	}
+
+	message := err.Error()
+	dbErr := &DBError{Err: err}
+	switch dialect {
+	case Postgres:
+		switch errorSQLState(err) {
+		case "23505":
+			dbErr.Kind = ErrUniqueViolation
+		case "23503":
+			dbErr.Kind = ErrForeignKeyViolation
+		case "23502":
+			dbErr.Kind = ErrNotNullViolation
+		case "23514":
+			dbErr.Kind = ErrCheckViolation
+		}
+		dbErr.Constraint = errorString(err, "ConstraintName", "Constraint")
+		dbErr.Table = errorString(err, "TableName", "Table")
+		dbErr.Column = errorString(err, "ColumnName", "Column")
+		if dbErr.Constraint == "" {
+			dbErr.Constraint = submatch(pgConstraintPattern, message)
+		}
+		if dbErr.Column == "" {
+			dbErr.Column = submatch(pgColumnPattern, message)
+		}
+	case MySQL:
+		number, _ := errorNumber(err)
+		switch number {
+		case 1062:
+			dbErr.Kind = ErrUniqueViolation
+			dbErr.Constraint = submatch(mysqlKeyPattern, message)
+		case 1451, 1452:
+			dbErr.Kind = ErrForeignKeyViolation
+		case 1048, 1364:
+			dbErr.Kind = ErrNotNullViolation
+		case 3819:
+			dbErr.Kind = ErrCheckViolation
+		}
+		if dbErr.Constraint == "" {
+			dbErr.Constraint = submatch(mysqlConstraintPattern, message)
+		}
+		dbErr.Column = submatch(mysqlColumnPattern, message)
+	case SQLServer:
+		number, _ := errorNumber(err)
+		switch number {
+		case 2627, 2601:
+			dbErr.Kind = ErrUniqueViolation
+		case 547:
+			// 547 reports both foreign key and check constraint conflicts
+			dbErr.Kind = ErrForeignKeyViolation
+			if strings.Contains(message, "CHECK constraint") {
+				dbErr.Kind = ErrCheckViolation
+			}
+		case 515:
+			dbErr.Kind = ErrNotNullViolation
+		}
+		dbErr.Constraint = submatch(sqlServerNamePattern, message)
+		dbErr.Table = submatch(sqlServerTablePattern, message)
+		dbErr.Column = submatch(sqlServerColumnPattern, message)
+	case SQLite:
+		switch {
+		case strings.Contains(message, "UNIQUE constraint failed"), strings.Contains(message, "PRIMARY KEY constraint failed"):
+			dbErr.Kind = ErrUniqueViolation
+		case strings.Contains(message, "FOREIGN KEY constraint failed"):
+			dbErr.Kind = ErrForeignKeyViolation
+		case strings.Contains(message, "NOT NULL constraint failed"):
+			dbErr.Kind = ErrNotNullViolation
+		case strings.Contains(message, "CHECK constraint failed"):
+			dbErr.Kind = ErrCheckViolation
+		}
+		// UNIQUE and NOT NULL report table.column, CHECK reports the constraint name
+		if name := submatch(sqliteColumnsPattern, message); name != "" {
+			if table, column, ok := strings.Cut(name, "."); ok {
+				dbErr.Table, dbErr.Column = table, column
+			} else {
+				dbErr.Constraint = name
+			}
+		}
+	case Oracle:
+		code, _ := strconv.Atoi(submatch(oracleCodePattern, message))
+		switch code {
+		case 1:
+			dbErr.Kind = ErrUniqueViolation
+		case 2291, 2292:
+			dbErr.Kind = ErrForeignKeyViolation
+		case 1400:
+			dbErr.Kind = ErrNotNullViolation
+		case 2290:
+			dbErr.Kind = ErrCheckViolation
+		}
+		dbErr.Constraint = submatch(oracleConstraintPattern, message)
+		if match := oracleColumnPattern.FindStringSubmatch(message); match != nil {
+			dbErr.Table, dbErr.Column = match[1], match[2]
+		}
+	}
+
+	if dbErr.Kind == nil {
+		return err
+	}
+	return dbErr
+}
+
+// errorString returns the first of the named string fields found on an error struct in the tree of err
+func errorString(err error, names ...string) string {
+	for _, name := range names {
+		if field, ok := errorField(err, name); ok && field.Kind() == reflect.String {
+			return field.String()
+		}
+	}
+	return ""
+}
+
+// submatch returns the first group of the pattern in s, or an empty string
+func submatch(pattern *regexp.Regexp, s string) string {
+	if match := pattern.FindStringSubmatch(s); match != nil {
+		return match[1]
+	}
+	return ""
+}
@@ -0,0 +1,200 @@
+package gobuilder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// pqError mimics lib/pq, which reports the constraint and column as fields
+type pqError struct {
+	Code       string
+	Message    string
+	Table      string
+	Column     string
+	Constraint string
+}
+
+func (e *pqError) Error() string { return "pq: " + e.Message }
+
+// messageError is a driver error identified only by its message, like go-ora and SQLite drivers
+type messageError struct{ message string }
+
+func (e messageError) Error() string { return e.message }
+
+// codedMssqlError mimics go-mssqldb with its message
+type codedMssqlError struct {
+	Number  int32
+	Message string
+}
+
+func (e codedMssqlError) Error() string { return "mssql: " + e.Message }
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialect    SQLDialect
+		err        error
+		kind       error
+		constraint string
+		table      string
+		column     string
+	}{
+		{
+			name:       "Postgres Unique Fields",
+			dialect:    Postgres,
+			err:        &pqError{Code: "23505", Message: "duplicate key value violates unique constraint \"users_email_key\"", Table: "users", Constraint: "users_email_key"},
+			kind:       ErrUniqueViolation,
+			constraint: "users_email_key",
+			table:      "users",
+		},
+		{
+			name:    "Postgres Not Null Message",
+			dialect: Postgres,
+			err:     &pgError{code: "23502"},
+			kind:    ErrNotNullViolation,
+		},
+		{
+			name:       "Postgres Foreign Key Wrapped",
+			dialect:    Postgres,
+			err:        fmt.Errorf("insert order: %w", &pqError{Code: "23503", Message: "insert or update on table \"orders\" violates foreign key constraint \"orders_user_id_fkey\""}),
+			kind:       ErrForeignKeyViolation,
+			constraint: "orders_user_id_fkey",
+		},
+		{
+			name:       "MySQL Duplicate Entry",
+			dialect:    MySQL,
+			err:        &mysqlError{Number: 1062, Message: "Duplicate entry 'ada@example' for key 'users.email_unique'"},
+			kind:       ErrUniqueViolation,
+			constraint: "users.email_unique",
+		},
+		{
+			name:       "MySQL Foreign Key",
+			dialect:    MySQL,
+			err:        &mysqlError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`shop`.`orders`, CONSTRAINT `fk_orders_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))"},
+			kind:       ErrForeignKeyViolation,
+			constraint: "fk_orders_user",
+		},
+		{
+			name:    "MySQL Not Null",
+			dialect: MySQL,
+			err:     &mysqlError{Number: 1048, Message: "Column 'email' cannot be null"},
+			kind:    ErrNotNullViolation,
+			column:  "email",
+		},
+		{
+			name:       "MySQL Check",
+			dialect:    MySQL,
+			err:        &mysqlError{Number: 3819, Message: "Check constraint 'positive_total' is violated."},
+			kind:       ErrCheckViolation,
+			constraint: "positive_total",
+		},
+		{
+			name:       "SQL Server Unique Key",
+			dialect:    SQLServer,
+			err:        codedMssqlError{Number: 2627, Message: "Violation of UNIQUE KEY constraint 'UQ_users_email'. Cannot insert duplicate key in object 'dbo.users'. The duplicate key value is (ada@example)."},
+			kind:       ErrUniqueViolation,
+			constraint: "UQ_users_email",
+			table:      "dbo.users",
+		},
+		{
+			name:       "SQL Server Check",
+			dialect:    SQLServer,
+			err:        codedMssqlError{Number: 547, Message: "The INSERT statement conflicted with the CHECK constraint \"CK_orders_total\". The conflict occurred in database \"shop\", table \"dbo.orders\", column 'total'."},
+			kind:       ErrCheckViolation,
+			constraint: "CK_orders_total",
+			column:     "total",
+		},
+		{
+			name:    "SQL Server Not Null",
+			dialect: SQLServer,
+			err:     codedMssqlError{Number: 515, Message: "Cannot insert the value NULL into column 'email', table 'shop.dbo.users'; column does not allow nulls. INSERT fails."},
+			kind:    ErrNotNullViolation,
+			table:   "shop.dbo.users",
+			column:  "email",
+		},
+		{
+			name:    "SQLite Unique",
+			dialect: SQLite,
+			err:     messageError{"UNIQUE constraint failed: users.email"},
+			kind:    ErrUniqueViolation,
+			table:   "users",
+			column:  "email",
+		},
+		{
+			name:       "SQLite Check",
+			dialect:    SQLite,
+			err:        messageError{"CHECK constraint failed: positive_total"},
+			kind:       ErrCheckViolation,
+			constraint: "positive_total",
+		},
+		{
+			name:       "Oracle Unique",
+			dialect:    Oracle,
+			err:        messageError{"ORA-00001: unique constraint (SHOP.USERS_EMAIL_UK) violated"},
+			kind:       ErrUniqueViolation,
+			constraint: "SHOP.USERS_EMAIL_UK",
+		},
+		{
+			name:    "Oracle Not Null",
+			dialect: Oracle,
+			err:     messageError{"ORA-01400: cannot insert NULL into (\"SHOP\".\"USERS\".\"EMAIL\")"},
+			kind:    ErrNotNullViolation,
+			table:   "USERS",
+			column:  "EMAIL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TranslateError(tt.dialect, tt.err)
+			if !errors.Is(err, tt.kind) {
+				t.Fatalf("expected %v, got %v", tt.kind, err)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected the driver error to be kept, got %v", err)
+			}
+
+			var dbErr *DBError
+			if !errors.As(err, &dbErr) {
+				t.Fatalf("expected a *DBError, got %T", err)
+			}
+			if dbErr.Constraint != tt.constraint || dbErr.Table != tt.table || dbErr.Column != tt.column {
+				t.Errorf("expected constraint %q, table %q and column %q, got %q, %q and %q",
+					tt.constraint, tt.table, tt.column, dbErr.Constraint, dbErr.Table, dbErr.Column)
+			}
+		})
+	}
+}
+
+func TestTranslateError_Passthrough(t *testing.T) {
+	failure := &pgError{code: "40001"}
+	if err := TranslateError(Postgres, failure); err != failure {
+		t.Errorf("expected non constraint errors to be returned as they are, got %v", err)
+	}
+	if err := TranslateError(MySQL, sql.ErrNoRows); !errors.Is(err, ErrNoRows) {
+		t.Errorf("expected %v, got %v", ErrNoRows, err)
+	}
+	if err := TranslateError(Postgres, nil); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestExec_TranslatesErrors(t *testing.T) {
+	db, _ := newFakeDB(func(query string, args []any) fakeResponse {
+		return fakeResponse{err: &mysqlError{Number: 1062, Message: "Duplicate entry 'ada@example' for key 'users.email_unique'"}}
+	})
+	defer db.Close()
+
+	_, err := NewGoBuilder(MySQL).Table("users").Create(map[string]any{"email": "ada@example"}).Exec(context.Background(), db)
+	if !errors.Is(err, ErrUniqueViolation) {
+		t.Errorf("expected %v, got %v", ErrUniqueViolation, err)
+	}
+
+	var driverErr *mysqlError
+	if !errors.As(err, &driverErr) || driverErr.Number != 1062 {
+		t.Errorf("expected the driver error to be reachable, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package gobuilder
+
+import "fmt"
+
+// ToCount rewrites the SELECT into a query counting its rows
+// ORDER BY and LIMIT are dropped; grouped, distinct and set operation queries are wrapped in a subquery
+// Example:
+//
+//	builder.Table("orders").Select("user_id").Where("status", "=", "paid").GroupBy("user_id").ToCount()
+//	// Generates: SELECT COUNT(*) FROM (SELECT user_id FROM orders WHERE status = $1 GROUP BY user_id) AS count_query
+func (gb *GoBuilder) ToCount() *GoBuilder {
+	*gb = *gb.countBuilder()
+	return gb
+}
+
+// ToExists rewrites the SELECT into a query returning whether it has any rows
+// PostgreSQL, MySQL and SQLite select EXISTS (...), SQL Server and Oracle a CASE returning 1 or 0
+// ORDER BY is dropped unless a LIMIT is set, since the offset changes the answer
+// Example:
+//
+//	builder.Table("users").Select().Where("email", "=", email).ToExists()
+//	// Generates: SELECT EXISTS (SELECT * FROM users WHERE email = $1)
+func (gb *GoBuilder) ToExists() *GoBuilder {
+	if gb.limitValues == nil {
+		gb.orderTerms = nil
+	}
+
+	// CTEs and the MySQL timeout hint belong to the outer SELECT
+	ctes, recursive, timeout := gb.cteClauses, gb.recursiveCTE, gb.timeout
+	gb.cteClauses, gb.recursiveCTE, gb.timeout = nil, false, 0
+	inner := gb.build()
+
+	exists := NewGoBuilder(gb.sqlDialect)
+	exists.err = gb.err
+	exists.timeout = timeout
+	exists.cteClauses, exists.recursiveCTE = ctes, recursive
+	exists.paramsClause = gb.paramsClause
+	switch gb.sqlDialect {
+	case SQLServer:
+		exists.selectClause = fmt.Sprintf("SELECT CASE WHEN EXISTS (%s) THEN 1 ELSE 0 END", inner)
+	case Oracle:
+		exists.selectClause = fmt.Sprintf("SELECT CASE WHEN EXISTS (%s) THEN 1 ELSE 0 END FROM DUAL", inner)
+	default:
+		exists.selectClause = fmt.Sprintf("SELECT EXISTS (%s)", inner)
+	}
+
+	*gb = *exists
+	return gb
+}
+
+// ToSum rewrites the SELECT into a query summing a column, returning 0 when there are no rows
+// ORDER BY and LIMIT are dropped; grouped, distinct and set operation queries are wrapped in a subquery,
+// in which case the column must be one of the selected columns
+// Example:
+//
+//	builder.Table("orders").Select().Where("status", "=", "paid").ToSum("total")
+//	// Generates: SELECT COALESCE(SUM(total), 0) FROM orders WHERE status = $1
+func (gb *GoBuilder) ToSum(column any) *GoBuilder {
+	if !isColumn(column) {
+		gb.err = fmt.Errorf("unsupported column type %T", column)
+		return gb
+	}
+	*gb = *gb.rewriteSelect(func(target *GoBuilder) string {
+		return fmt.Sprintf("COALESCE(SUM(%s), 0)", target.terms([]any{column})[0])
+	}, false, "sum_query")
+	return gb
+}
+
+// Pluck rewrites the SELECT into a query returning a single column, keeping ORDER BY and LIMIT
+// When the query is wrapped, ORDER BY terms that are not selected are carried through the subquery
+// Example:
+//
+//	builder.Table("users").Select().Where("active", "=", true).OrderBy("name").Pluck("email")
+//	// Generates: SELECT email FROM users WHERE active = $1 ORDER BY name ASC
+func (gb *GoBuilder) Pluck(column any) *GoBuilder {
+	if !isColumn(column) {
+		gb.err = fmt.Errorf("unsupported column type %T", column)
+		return gb
+	}
+	*gb = *gb.rewriteSelect(func(target *GoBuilder) string {
+		col, _ := target.selectItem(column)
+		return col
+	}, true, "pluck_query")
+	return gb
+}
+
+// isColumn reports whether the value is a column name or an expression
+func isColumn(column any) bool {
+	switch column.(type) {
+	case string, Expr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package gobuilder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDerivedQueries(t *testing.T) {
+	tests := []sqlTestCase{
+		{
+			name:    "Count",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").Select("id", "name").Where("active", "=", true).OrderBy("name").Limit(0, 10).ToCount()
+			},
+			expected: "SELECT COUNT(*) FROM users WHERE active = $1",
+			params:   []any{true},
+		},
+		{
+			name:    "Count Of Union",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				leads := NewGoBuilder(MySQL).Table("leads").Select("email").Where("source", "=", "ads")
+				return gb.Table("users").Select("email").Where("active", "=", true).Union(leads).OrderBy("email").ToCount()
+			},
+			expected: "SELECT COUNT(*) FROM (SELECT email FROM users WHERE active = ? UNION SELECT email FROM leads WHERE source = ?) AS count_query",
+			params:   []any{true, "ads"},
+		},
+		{
+			name:    "Exists Postgres",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").Select().Where("email", "=", "ada@example").OrderBy("id").ToExists()
+			},
+			expected: "SELECT EXISTS (SELECT * FROM users WHERE email = $1)",
+			params:   []any{"ada@example"},
+		},
+		{
+			name:    "Exists SQL Server",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").Select().Where("email", "=", "ada@example").ToExists()
+			},
+			expected: "SELECT CASE WHEN EXISTS (SELECT * FROM users WHERE email = @1) THEN 1 ELSE 0 END",
+			params:   []any{"ada@example"},
+		},
+		{
+			name:    "Exists Oracle Keeps Limit",
+			dialect: Oracle,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").Select().Where("team", "=", "core").OrderBy("id").Limit(20, 10).ToExists()
+			},
+			expected: "SELECT CASE WHEN EXISTS (SELECT * FROM users WHERE team = :1 ORDER BY id ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY) THEN 1 ELSE 0 END FROM DUAL",
+			params:   []any{"core"},
+		},
+		{
+			name:    "Exists MySQL Timeout Hint On Outer Select",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").Select().Where("email", "=", "ada@example").WithTimeout(time.Second).ToExists()
+			},
+			expected: "SELECT /*+ MAX_EXECUTION_TIME(1000) */ EXISTS (SELECT * FROM users WHERE email = ?)",
+			params:   []any{"ada@example"},
+		},
+		{
+			name:    "Count MySQL Timeout Hint On Outer Select",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").Select("status").GroupBy("status").WithTimeout(time.Second).ToCount()
+			},
+			expected: "SELECT /*+ MAX_EXECUTION_TIME(1000) */ COUNT(*) FROM (SELECT status FROM orders GROUP BY status) AS count_query",
+			params:   []any{},
+		},
+		{
+			name:    "Sum",
+			dialect: SQLite,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").Select().Where("status", "=", "paid").OrderBy("id").ToSum("total")
+			},
+			expected: "SELECT COALESCE(SUM(total), 0) FROM orders WHERE status = ?",
+			params:   []any{"paid"},
+		},
+		{
+			name:    "Sum Of Expression Over Distinct Rows",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").SelectDistinct("user_id", "total").Where("status", "=", "paid").
+					ToSum(Col("total").Mul(Lit(2)))
+			},
+			expected: "SELECT COALESCE(SUM((total * $1)), 0) FROM (SELECT DISTINCT user_id, total FROM orders WHERE status = $2) AS sum_query",
+			params:   []any{2, "paid"},
+		},
+		{
+			name:    "Pluck Keeps Order And Limit",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").Select("id", "name").Where("active", "=", true).OrderBy("name").Limit(0, 5).Pluck("email")
+			},
+			expected: "SELECT email FROM users WHERE active = $1 ORDER BY name ASC OFFSET 0 LIMIT 5",
+			params:   []any{true},
+		},
+		{
+			name:    "Pluck From Grouped Query",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").Select("user_id", "SUM(total) as total").GroupBy("user_id").
+					OrderByDesc("total").Pluck("user_id")
+			},
+			expected: "SELECT user_id FROM (SELECT user_id, SUM(total) as total FROM orders GROUP BY user_id) AS pluck_query ORDER BY total DESC",
+			params:   []any{},
+		},
+		{
+			name:    "Pluck From Grouped Query Ordered By Aggregate",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("t").Select("user_id").GroupBy("user_id").OrderByDescExpr(Sum(Col("x"))).Limit(0, 5).Pluck("user_id")
+			},
+			expected: "SELECT user_id FROM (SELECT user_id, SUM(x) AS gobuilder_order_1 FROM t GROUP BY user_id) AS pluck_query ORDER BY gobuilder_order_1 DESC LIMIT 5 OFFSET 0",
+			params:   []any{},
+		},
+		{
+			name:    "Pluck From Distinct On Query Ordered By Unselected Column",
+			dialect: SQLite,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").DistinctOn("user_id").Select("user_id", "id").
+					OrderByExpr("user_id", Desc(Col("created_at"))).Pluck("id")
+			},
+			expected: "SELECT id FROM (SELECT user_id, id, gobuilder_order_2 FROM (SELECT user_id, id, created_at AS gobuilder_order_2, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY user_id ASC, created_at DESC) AS gobuilder_rn FROM orders) AS distinct_on WHERE gobuilder_rn = 1) AS pluck_query ORDER BY user_id ASC, gobuilder_order_2 DESC",
+			params:   []any{},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestDerivedQueries_InvalidColumn(t *testing.T) {
+	if NewGoBuilder(Postgres).Table("orders").Select().ToSum(42).Error() == nil {
+		t.Error("expected error for ToSum(42)")
+	}
+	if NewGoBuilder(Postgres).Table("orders").Select().Pluck(42).Error() == nil {
+		t.Error("expected error for Pluck(42)")
+	}
+}
@@ -0,0 +1,133 @@
+package gobuilder
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// rowNumberColumn is the column numbering the rows of each group in the DISTINCT ON emulation
+const rowNumberColumn = "gobuilder_rn"
+
+// orderColumnPrefix names the columns carrying ORDER BY terms that are not selected out of the emulation
+const orderColumnPrefix = "gobuilder_order"
+
+// identifierPattern matches plain and table qualified column names
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// DistinctOn keeps only the first row, in ORDER BY order, of each group of rows with equal terms
+// PostgreSQL renders SELECT DISTINCT ON (...). Other dialects number the rows of each group with
+// ROW_NUMBER() OVER (PARTITION BY ... ORDER BY ...) in a subquery and keep the first one; the outer
+// query selects the column names of the inner one, or * when a column has no name, in which case
+// the gobuilder_rn column is returned as well. ORDER BY terms that are not selected are added to the
+// subquery as gobuilder_order_n columns, so the outer query can still sort by them
+// Example:
+//
+//	builder.Table("orders").DistinctOn("user_id").Select("user_id", "id", "created_at").OrderByExpr("user_id", Desc("created_at"))
+//	// PostgreSQL: SELECT DISTINCT ON (user_id) user_id, id, created_at FROM orders ORDER BY user_id ASC, created_at DESC
+//	// MySQL: SELECT user_id, id, created_at FROM (SELECT user_id, id, created_at, ROW_NUMBER() OVER
+//	//   (PARTITION BY user_id ORDER BY user_id ASC, created_at DESC) AS gobuilder_rn FROM orders) AS distinct_on
+//	//   WHERE gobuilder_rn = 1 ORDER BY user_id ASC, created_at DESC
+func (gb *GoBuilder) DistinctOn(columns ...any) *GoBuilder {
+	gb.distinctOn = append(gb.distinctOn, gb.terms(columns)...)
+	return gb
+}
+
+// Private method to render a query with DISTINCT ON terms
+// The ORDER BY terms always pick the first row of each group; ordered tells whether they also sort
+// the result of the emulation, which a derived table on SQL Server does not allow without a limit
+func (gb *GoBuilder) buildDistinctOn(ordered bool) string {
+	query := gb.Clone()
+	query.distinctOn = nil
+
+	from := " FROM " + gb.tableClause
+	if !strings.HasPrefix(query.selectClause, "SELECT ") || !strings.HasSuffix(query.selectClause, from) {
+		return query.build()
+	}
+	columns := strings.TrimSuffix(strings.TrimPrefix(query.selectClause, "SELECT "), from)
+	keys := strings.Join(gb.distinctOn, ", ")
+
+	if gb.sqlDialect == Postgres {
+		query.selectClause = fmt.Sprintf("SELECT DISTINCT ON (%s) %s%s", keys, columns, from)
+		return query.build()
+	}
+
+	order := keys
+	if len(gb.orderTerms) > 0 {
+		order = gb.orderList(gb.orderTerms)
+	}
+	// ORDER BY terms that are not selected are carried through the subquery as extra columns
+	var outerOrder []orderTerm
+	if ordered {
+		var extra []string
+		extra, outerOrder = gb.carryOrder(gb.orderTerms)
+		columns = strings.Join(append([]string{columns}, extra...), ", ")
+	}
+	query.selectClause = fmt.Sprintf("SELECT %s, ROW_NUMBER() OVER (PARTITION BY %s ORDER BY %s) AS %s%s",
+		columns, keys, order, rowNumberColumn, from)
+	query.orderTerms, query.limitValues = nil, nil
+	query.cteClauses, query.recursiveCTE = nil, false
+	// The MySQL timeout hint only applies to the outermost SELECT
+	query.timeout = 0
+
+	alias := "AS distinct_on"
+	if gb.sqlDialect == Oracle {
+		alias = "distinct_on"
+	}
+
+	outer := NewGoBuilder(gb.sqlDialect)
+	outer.cteClauses, outer.recursiveCTE = gb.cteClauses, gb.recursiveCTE
+	outer.timeout = gb.timeout
+	outer.selectClause = fmt.Sprintf("SELECT %s FROM (%s) %s", outputNames(gb.selectColumns), query.build(), alias)
+	outer.whereClause = fmt.Sprintf("WHERE %s = 1", rowNumberColumn)
+	outer.limitValues = gb.limitValues
+	outer.orderTerms = outerOrder
+	return outer.build()
+}
+
+// Private method to carry ORDER BY terms through a derived table
+// Terms naming a selected column order the outer query by that name; the others are returned as
+// gobuilder_order_n columns to add to the derived table, which the outer query orders by instead
+func (gb *GoBuilder) carryOrder(terms []orderTerm) ([]string, []orderTerm) {
+	selected := make(map[string]bool, len(gb.selectColumns))
+	for _, column := range gb.selectColumns {
+		selected[outputName(column)] = true
+	}
+	var extra []string
+	outer := make([]orderTerm, 0, len(terms))
+	for i, term := range terms {
+		if name := outputName(term.sql); name != "" && selected[name] {
+			term.sql = name
+		} else if term.direction != "" {
+			name = fmt.Sprintf("%s_%d", orderColumnPrefix, i+1)
+			extra = append(extra, fmt.Sprintf("%s AS %s", term.sql, name))
+			term.sql = name
+		}
+		outer = append(outer, term)
+	}
+	return extra, outer
+}
+
+// outputNames returns the names of the selected columns, or * when any of them has no name
+func outputNames(columns []string) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = outputName(column)
+		if names[i] == "" {
+			return "*"
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// outputName returns the name a column is known by outside its query: its alias, or the column
+// name without table qualifier; empty when the column is an expression without alias
+func outputName(column string) string {
+	if i := strings.LastIndex(strings.ToLower(column), " as "); i >= 0 {
+		return strings.TrimSpace(column[i+len(" as "):])
+	}
+	if identifierPattern.MatchString(column) {
+		return column[strings.LastIndex(column, ".")+1:]
+	}
+	return ""
+}
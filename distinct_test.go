@@ -0,0 +1,118 @@
+package gobuilder
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDistinctOn(t *testing.T) {
+	tests := []sqlTestCase{
+		{
+			name:    "Postgres Native",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").DistinctOn("user_id").Select("user_id", "id", "created_at").
+					Where("status", "=", "paid").OrderByExpr("user_id", Desc("created_at"))
+			},
+			expected: "SELECT DISTINCT ON (user_id) user_id, id, created_at FROM orders WHERE status = $1 ORDER BY user_id ASC, created_at DESC",
+			params:   []any{"paid"},
+		},
+		{
+			name:    "MySQL Emulation",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").DistinctOn("user_id").Select("user_id", "id", "created_at").
+					Where("status", "=", "paid").OrderByExpr("user_id", Desc("created_at")).Limit(0, 10)
+			},
+			expected: "SELECT user_id, id, created_at FROM (SELECT user_id, id, created_at, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY user_id ASC, created_at DESC) AS gobuilder_rn FROM orders WHERE status = ?) AS distinct_on WHERE gobuilder_rn = 1 ORDER BY user_id ASC, created_at DESC LIMIT 10 OFFSET 0",
+			params:   []any{"paid"},
+		},
+		{
+			name:    "Qualified Columns And Aliases",
+			dialect: SQLite,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").DistinctOn("orders.user_id").Select("orders.user_id", "orders.total as amount").
+					OrderByExpr("orders.user_id", Desc("orders.total"))
+			},
+			expected: "SELECT user_id, amount FROM (SELECT orders.user_id, orders.total as amount, orders.total AS gobuilder_order_2, ROW_NUMBER() OVER (PARTITION BY orders.user_id ORDER BY orders.user_id ASC, orders.total DESC) AS gobuilder_rn FROM orders) AS distinct_on WHERE gobuilder_rn = 1 ORDER BY user_id ASC, gobuilder_order_2 DESC",
+			params:   []any{},
+		},
+		{
+			name:    "Order Column Not Selected",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").DistinctOn("user_id").Select("user_id", "id").OrderByExpr("user_id", Desc("created_at"))
+			},
+			expected: "SELECT user_id, id FROM (SELECT user_id, id, created_at AS gobuilder_order_2, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY user_id ASC, created_at DESC) AS gobuilder_rn FROM orders) AS distinct_on WHERE gobuilder_rn = 1 ORDER BY user_id ASC, gobuilder_order_2 DESC",
+			params:   []any{},
+		},
+		{
+			name:    "MySQL Timeout Hint On Outer Query",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").DistinctOn("user_id").Select("user_id", "id").
+					OrderByExpr("user_id", Desc("id")).WithTimeout(2 * time.Second)
+			},
+			expected: "SELECT /*+ MAX_EXECUTION_TIME(2000) */ user_id, id FROM (SELECT user_id, id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY user_id ASC, id DESC) AS gobuilder_rn FROM orders) AS distinct_on WHERE gobuilder_rn = 1 ORDER BY user_id ASC, id DESC",
+			params:   []any{},
+		},
+		{
+			name:    "Without Order Or Names",
+			dialect: Oracle,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("events").DistinctOn("device_id").Select()
+			},
+			expected: "SELECT * FROM (SELECT *, ROW_NUMBER() OVER (PARTITION BY device_id ORDER BY device_id) AS gobuilder_rn FROM events) distinct_on WHERE gobuilder_rn = 1",
+			params:   []any{},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestDistinctOn_Count(t *testing.T) {
+	query, params := NewGoBuilder(SQLServer).Table("orders").DistinctOn("user_id").Select("user_id", "id").
+		Where("status", "=", "paid").OrderBy("user_id").ToCount().Prepare()
+	expected := "SELECT COUNT(*) FROM (SELECT user_id, id FROM (SELECT user_id, id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY user_id ASC) AS gobuilder_rn FROM orders WHERE status = @1) AS distinct_on WHERE gobuilder_rn = 1) AS count_query"
+	if query != expected {
+		t.Errorf("expected query %v, got %v", expected, query)
+	}
+	if !reflect.DeepEqual(params, []any{"paid"}) {
+		t.Errorf("expected params %v, got %v", []any{"paid"}, params)
+	}
+}
+
+func TestCountDistinct(t *testing.T) {
+	tests := []sqlTestCase{
+		{
+			name:    "Plain",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").SelectExpr(CountDistinct(Col("user_id")).As("buyers"))
+			},
+			expected: "SELECT COUNT(DISTINCT user_id) as buyers FROM orders",
+			params:   []any{},
+		},
+		{
+			name:    "Native Filter",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").SelectExpr(CountDistinct(Col("user_id")).Filter(Cond().Where("status", "=", "paid")).As("buyers"))
+			},
+			expected: "SELECT COUNT(DISTINCT user_id) FILTER (WHERE status = $1) as buyers FROM orders",
+			params:   []any{"paid"},
+		},
+		{
+			name:    "Emulated Filter",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").SelectExpr(CountDistinct(Col("user_id")).Filter(Cond().Where("status", "=", "paid")).As("buyers"))
+			},
+			expected: "SELECT COUNT(DISTINCT CASE WHEN status = @1 THEN user_id END) as buyers FROM orders",
+			params:   []any{"paid"},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
@@ -0,0 +1,95 @@
+package gobuilder
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Executor runs SQL statements; *sql.DB, *sql.Tx and *sql.Conn all satisfy it
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Exec prepares the statement and runs it with the executor
+// Constraint violations are returned as *DBError, see TranslateError. The statement is bounded by
+// Timeout or WithTimeout when ctx has no deadline. Like Prepare, it resets the builder
+// Example:
+//
+//	result, err := builder.Table("users").Update(map[string]any{"active": false}).Where("id", "=", 7).Exec(ctx, db)
+func (gb *GoBuilder) Exec(ctx context.Context, ex Executor) (sql.Result, error) {
+	if gb.err != nil {
+		return nil, gb.err
+	}
+	ctx, cancel := gb.timeoutContext(ctx)
+	defer cancel()
+
+	dialect, timeout := gb.sqlDialect, gb.timeout
+	query, params := gb.Prepare()
+	if err := setStatementTimeout(ctx, ex, dialect, timeout); err != nil {
+		return nil, err
+	}
+	result, err := ex.ExecContext(ctx, query, params...)
+	return result, TranslateError(dialect, err)
+}
+
+// Query prepares the statement, runs it with the executor and returns every row as a column map
+// Constraint violations are returned as *DBError, see TranslateError. The statement is bounded by
+// Timeout or WithTimeout when ctx has no deadline. Like Prepare, it resets the builder
+// Example:
+//
+//	rows, err := builder.Table("users").Select("id", "name").Where("active", "=", true).Query(ctx, db)
+func (gb *GoBuilder) Query(ctx context.Context, ex Executor) ([]map[string]any, error) {
+	if gb.err != nil {
+		return nil, gb.err
+	}
+	ctx, cancel := gb.timeoutContext(ctx)
+	defer cancel()
+
+	dialect, timeout := gb.sqlDialect, gb.timeout
+	query, params := gb.Prepare()
+	if err := setStatementTimeout(ctx, ex, dialect, timeout); err != nil {
+		return nil, err
+	}
+	rows, err := ex.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, TranslateError(dialect, err)
+	}
+	items, err := scanRows(rows)
+	return items, TranslateError(dialect, err)
+}
+
+// scanRows reads all rows into column maps and closes them
+// []byte values are returned as strings, since most drivers use them for textual columns
+func scanRows(rows *sql.Rows) ([]map[string]any, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]any, 0)
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
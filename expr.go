@@ -0,0 +1,296 @@
+package gobuilder
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Expr is a typed SQL expression accepted by Select, OrderBy, OrderByDesc, GroupBy and Having
+// Wherever an expression expects an operand, values that are not expressions are bound as parameters
+type Expr interface {
+	toSQL(gb *GoBuilder) string
+}
+
+// Expression is the expression type returned by the constructors of this package
+// Methods return a new expression, so a shared expression can be reused safely
+type Expression struct {
+	render    func(gb *GoBuilder) string // Renders the expression for the builder's dialect
+	alias     string                     // The alias used when the expression is selected
+	aggregate string                     // The aggregate function name, for expressions built by Count, Sum, ...
+	argument  Expr                       // The aggregated expression, nil for COUNT(*)
+	distinct  bool                       // Whether the aggregate only considers distinct values
+}
+
+// aliased is implemented by expressions that carry a SELECT alias
+type aliased interface {
+	aliasName() string
+}
+
+func (e *Expression) toSQL(gb *GoBuilder) string {
+	return e.render(gb)
+}
+
+func (e *Expression) aliasName() string {
+	return e.alias
+}
+
+// As sets the alias used when the expression appears in a SELECT list
+// Example:
+//
+//	Sum(Col("amount")).As("total")
+//	// Generates: SUM(amount) as total
+func (e *Expression) As(alias string) *Expression {
+	copied := *e
+	copied.alias = sanitizeIdentifier(alias)
+	return &copied
+}
+
+// Add returns the expression "(e + other)"
+func (e *Expression) Add(other any) *Expression {
+	return e.binary("+", other)
+}
+
+// Sub returns the expression "(e - other)"
+func (e *Expression) Sub(other any) *Expression {
+	return e.binary("-", other)
+}
+
+// Mul returns the expression "(e * other)"
+func (e *Expression) Mul(other any) *Expression {
+	return e.binary("*", other)
+}
+
+// Div returns the expression "(e / other)"
+func (e *Expression) Div(other any) *Expression {
+	return e.binary("/", other)
+}
+
+// Eq returns the comparison "e = other"
+func (e *Expression) Eq(other any) *Expression {
+	return e.compare("=", other)
+}
+
+// Ne returns the comparison "e <> other"
+func (e *Expression) Ne(other any) *Expression {
+	return e.compare("<>", other)
+}
+
+// Gt returns the comparison "e > other"
+func (e *Expression) Gt(other any) *Expression {
+	return e.compare(">", other)
+}
+
+// Gte returns the comparison "e >= other"
+func (e *Expression) Gte(other any) *Expression {
+	return e.compare(">=", other)
+}
+
+// Lt returns the comparison "e < other"
+func (e *Expression) Lt(other any) *Expression {
+	return e.compare("<", other)
+}
+
+// Lte returns the comparison "e <= other"
+func (e *Expression) Lte(other any) *Expression {
+	return e.compare("<=", other)
+}
+
+// Private method to build arithmetic expressions
+func (e *Expression) binary(operator string, other any) *Expression {
+	return &Expression{render: func(gb *GoBuilder) string {
+		return fmt.Sprintf("(%s %s %s)", e.render(gb), operator, operand(gb, other))
+	}}
+}
+
+// Private method to build comparisons
+func (e *Expression) compare(operator string, other any) *Expression {
+	return &Expression{render: func(gb *GoBuilder) string {
+		return fmt.Sprintf("%s %s %s", e.render(gb), operator, operand(gb, other))
+	}}
+}
+
+// Col references a column, optionally qualified with a table name ("users.id", "users.*")
+func Col(name string) *Expression {
+	return &Expression{render: func(gb *GoBuilder) string {
+		if name == "*" {
+			return name
+		}
+		if prefix, ok := strings.CutSuffix(name, ".*"); ok {
+			return sanitizeIdentifier(prefix) + ".*"
+		}
+		return sanitizeIdentifier(name)
+	}}
+}
+
+// Lit is a literal value, always bound as a parameter
+func Lit(value any) *Expression {
+	return &Expression{render: func(gb *GoBuilder) string {
+		return gb.addParam(value)
+	}}
+}
+
+// Func calls an SQL function with the given arguments
+// Arguments that are not expressions are bound as parameters
+// Example:
+//
+//	Func("ROUND", Col("price"), 2)
+//	// Generates: ROUND(price, $1)
+func Func(name string, args ...any) *Expression {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' {
+			return r
+		}
+		return -1
+	}, name)
+
+	return &Expression{render: func(gb *GoBuilder) string {
+		operands := make([]string, len(args))
+		for i, arg := range args {
+			operands[i] = operand(gb, arg)
+		}
+		return fmt.Sprintf("%s(%s)", strings.ToUpper(name), strings.Join(operands, ", "))
+	}}
+}
+
+// Count returns COUNT(*) when called without arguments, or COUNT(expr)
+func Count(exprs ...Expr) *Expression {
+	if len(exprs) == 0 {
+		return &Expression{render: func(gb *GoBuilder) string { return "COUNT(*)" }, aggregate: "COUNT"}
+	}
+	return aggregate("COUNT", exprs[0])
+}
+
+// CountDistinct returns COUNT(DISTINCT expr)
+func CountDistinct(expr Expr) *Expression {
+	return &Expression{render: func(gb *GoBuilder) string {
+		return fmt.Sprintf("COUNT(DISTINCT %s)", expr.toSQL(gb))
+	}, aggregate: "COUNT", argument: expr, distinct: true}
+}
+
+// Sum returns SUM(expr)
+func Sum(expr Expr) *Expression {
+	return aggregate("SUM", expr)
+}
+
+// Avg returns AVG(expr)
+func Avg(expr Expr) *Expression {
+	return aggregate("AVG", expr)
+}
+
+// Min returns MIN(expr)
+func Min(expr Expr) *Expression {
+	return aggregate("MIN", expr)
+}
+
+// Max returns MAX(expr)
+func Max(expr Expr) *Expression {
+	return aggregate("MAX", expr)
+}
+
+// Coalesce returns the first non-null argument
+// Example:
+//
+//	Coalesce(Col("nickname"), Col("name"), "anonymous").As("display_name")
+//	// Generates: COALESCE(nickname, name, $1) as display_name
+func Coalesce(args ...any) *Expression {
+	return Func("COALESCE", args...)
+}
+
+// castTypePattern matches the type names accepted by Cast, such as "integer", "double precision" and "numeric(10, 2)"
+var castTypePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_ ]*(\(\d+(,\s*\d+)?\))?$`)
+
+// Cast converts a value to the given type
+// Portable type names are translated per dialect, for example "text" becomes CHAR in MySQL,
+// NVARCHAR(MAX) in SQL Server and VARCHAR2(4000) in Oracle; other type names are used as given,
+// and type names with other characters than letters, digits, spaces and a length set an error
+func Cast(value any, typeName string) *Expression {
+	return &Expression{render: func(gb *GoBuilder) string {
+		return fmt.Sprintf("CAST(%s AS %s)", operand(gb, value), gb.castType(typeName))
+	}}
+}
+
+// aggregate builds a single argument aggregate function
+func aggregate(name string, expr Expr) *Expression {
+	return &Expression{render: func(gb *GoBuilder) string {
+		return fmt.Sprintf("%s(%s)", name, expr.toSQL(gb))
+	}, aggregate: name, argument: expr}
+}
+
+// operand renders an expression, or binds any other value as a parameter
+func operand(gb *GoBuilder, value any) string {
+	if expr, ok := value.(Expr); ok {
+		return expr.toSQL(gb)
+	}
+	return gb.addParam(value)
+}
+
+// Private method to render an expression as a SELECT column, including its alias
+func (gb *GoBuilder) selectExpr(expr Expr) string {
+	sql := expr.toSQL(gb)
+	if a, ok := expr.(aliased); ok && a.aliasName() != "" {
+		sql = fmt.Sprintf("%s as %s", sql, a.aliasName())
+	}
+	return sql
+}
+
+// Private method to render ORDER BY and GROUP BY terms given as strings or expressions
+func (gb *GoBuilder) terms(columns []any) []string {
+	rendered := make([]string, 0, len(columns))
+	for _, column := range columns {
+		switch col := column.(type) {
+		case string:
+			rendered = append(rendered, col)
+		case Expr:
+			rendered = append(rendered, col.toSQL(gb))
+		default:
+			gb.err = fmt.Errorf("unsupported column type %T", column)
+		}
+	}
+	return rendered
+}
+
+// Private method to translate portable type names for CAST
+// Type names that are not a name with an optional length or precision set an error
+func (gb *GoBuilder) castType(typeName string) string {
+	if !castTypePattern.MatchString(strings.TrimSpace(typeName)) {
+		gb.err = fmt.Errorf("invalid type name %q", typeName)
+		return "invalid_type"
+	}
+	upper := strings.ToUpper(strings.TrimSpace(typeName))
+	switch gb.sqlDialect {
+	case MySQL:
+		switch upper {
+		case "INT", "INTEGER", "BIGINT", "SMALLINT":
+			return "SIGNED"
+		case "TEXT", "VARCHAR", "STRING":
+			return "CHAR"
+		case "TIMESTAMP":
+			return "DATETIME"
+		}
+	case SQLServer:
+		switch upper {
+		case "TEXT", "STRING":
+			return "NVARCHAR(MAX)"
+		case "BOOLEAN", "BOOL":
+			return "BIT"
+		case "TIMESTAMP":
+			return "DATETIME2"
+		}
+	case Oracle:
+		switch upper {
+		case "TEXT", "STRING", "VARCHAR":
+			return "VARCHAR2(4000)"
+		case "INT", "BIGINT", "SMALLINT":
+			return "INTEGER"
+		case "BOOLEAN", "BOOL":
+			return "NUMBER(1)"
+		}
+	default:
+		if upper == "STRING" {
+			return "TEXT"
+		}
+	}
+	return upper
+}
@@ -0,0 +1,127 @@
+package gobuilder
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpr(t *testing.T) {
+	tests := []sqlTestCase{
+		{
+			name:    "Aggregates With Aliases",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").
+					SelectExpr(Col("customer_id"), Count().As("orders"), Sum(Col("amount")).As("total")).
+					GroupByExpr(Col("customer_id")).
+					Having(Sum(Col("amount")).Gt(1000)).
+					OrderByDescExpr(Sum(Col("amount")))
+			},
+			expected: "SELECT customer_id, COUNT(*) as orders, SUM(amount) as total FROM orders GROUP BY customer_id HAVING SUM(amount) > $1 ORDER BY SUM(amount) DESC",
+			params:   []any{1000},
+		},
+		{
+			name:    "Column Named Summary",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("reports").SelectExpr(Col("summary"), Col("reports.*"))
+			},
+			expected: "SELECT summary, reports.* FROM reports",
+			params:   []any{},
+		},
+		{
+			name:    "Arithmetic And Literals",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("items").
+					SelectExpr(Col("price").Mul(Col("quantity")).Sub(Lit(5)).As("net")).
+					Where("status", "=", "active")
+			},
+			expected: "SELECT ((price * quantity) - $1) as net FROM items WHERE status = $2",
+			params:   []any{5, "active"},
+		},
+		{
+			name:    "Coalesce And Func",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").
+					SelectExpr(Coalesce(Col("nickname"), Col("name"), "anonymous").As("display_name"), Func("round", Avg(Col("score")), 2))
+			},
+			expected: "SELECT COALESCE(nickname, name, ?) as display_name, ROUND(AVG(score), ?) FROM users",
+			params:   []any{"anonymous", 2},
+		},
+		{
+			name:    "Cast Postgres",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").SelectExpr(Cast(Col("id"), "text").As("id_text"))
+			},
+			expected: "SELECT CAST(id AS TEXT) as id_text FROM users",
+			params:   []any{},
+		},
+		{
+			name:    "Cast MySQL",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").SelectExpr(Cast(Col("id"), "text"), Cast(Col("age"), "integer"))
+			},
+			expected: "SELECT CAST(id AS CHAR), CAST(age AS SIGNED) FROM users",
+			params:   []any{},
+		},
+		{
+			name:    "Cast SQL Server",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").SelectExpr(Cast(Col("id"), "text"))
+			},
+			expected: "SELECT CAST(id AS NVARCHAR(MAX)) FROM users",
+			params:   []any{},
+		},
+		{
+			name:    "Cast Oracle",
+			dialect: Oracle,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").SelectExpr(Cast(Col("id"), "text")).Where("active", "=", 1)
+			},
+			expected: "SELECT CAST(id AS VARCHAR2(4000)) FROM users WHERE active = :1",
+			params:   []any{1},
+		},
+		{
+			name:    "Mixed Strings And Expressions",
+			dialect: SQLite,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").
+					SelectExpr("status", Max(Col("created_at")).As("latest")).
+					GroupBy("status").
+					OrderBy("status")
+			},
+			expected: "SELECT status, MAX(created_at) as latest FROM orders GROUP BY status ORDER BY status ASC",
+			params:   []any{},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestExpr_UnsupportedColumn(t *testing.T) {
+	gb := NewGoBuilder(Postgres).Table("users").SelectExpr(42)
+	if gb.Error() == nil {
+		t.Error("expected error for unsupported column type")
+	}
+}
+
+func TestExpr_CastTypeName(t *testing.T) {
+	gb := NewGoBuilder(Postgres).Table("users").SelectExpr(Cast(Col("a"), "int) FROM t; DROP TABLE users; --"))
+	if gb.Error() == nil {
+		t.Error("expected error for an invalid type name")
+	}
+	if query := gb.Sql(); strings.Contains(query, "DROP") {
+		t.Errorf("expected the type name to be left out, got %v", query)
+	}
+
+	for _, typeName := range []string{"integer", "double precision", "numeric(10, 2)", "VARCHAR(255)"} {
+		if err := NewGoBuilder(Postgres).Table("users").SelectExpr(Cast(Col("a"), typeName)).Error(); err != nil {
+			t.Errorf("unexpected error for %q: %v", typeName, err)
+		}
+	}
+}
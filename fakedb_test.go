@@ -0,0 +1,145 @@
+package gobuilder
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// fakeResponse is what the fake database answers to a statement
+type fakeResponse struct {
+	columns      []string
+	rows         [][]driver.Value
+	rowsAffected int64
+	err          error
+}
+
+// fakeStatement is a statement received by the fake database
+type fakeStatement struct {
+	query    string
+	args     []any
+	deadline time.Time // The deadline of the statement context, zero when it has none
+}
+
+// fakeDB is an in-memory database/sql driver that records statements and answers them with respond
+// BEGIN, COMMIT and ROLLBACK are recorded as statements too
+type fakeDB struct {
+	mu         sync.Mutex
+	statements []fakeStatement
+	respond    func(query string, args []any) fakeResponse
+}
+
+// newFakeDB opens a *sql.DB backed by a fakeDB
+func newFakeDB(respond func(query string, args []any) fakeResponse) (*sql.DB, *fakeDB) {
+	fake := &fakeDB{respond: respond}
+	return sql.OpenDB(fake), fake
+}
+
+// queries returns the recorded statements
+func (f *fakeDB) queries() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	queries := make([]string, len(f.statements))
+	for i, s := range f.statements {
+		queries[i] = s.query
+	}
+	return queries
+}
+
+// deadline returns the context deadline of the n-th recorded statement
+func (f *fakeDB) deadline(n int) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.statements[n].deadline
+}
+
+// args returns the arguments of the n-th recorded statement
+func (f *fakeDB) args(n int) []any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.statements[n].args
+}
+
+func (f *fakeDB) handle(ctx context.Context, query string, named []driver.NamedValue) fakeResponse {
+	args := make([]any, len(named))
+	for i, nv := range named {
+		args[i] = nv.Value
+	}
+
+	f.mu.Lock()
+	deadline, _ := ctx.Deadline()
+	f.statements = append(f.statements, fakeStatement{query: query, args: args, deadline: deadline})
+	f.mu.Unlock()
+
+	if f.respond == nil {
+		return fakeResponse{}
+	}
+	return f.respond(query, args)
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *fakeDB) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errors.New("use newFakeDB") }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if resp := c.db.handle(ctx, "BEGIN", nil); resp.err != nil {
+		return nil, resp.err
+	}
+	return &fakeTx{conn: c}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	resp := c.db.handle(ctx, query, args)
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	return driver.RowsAffected(resp.rowsAffected), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	resp := c.db.handle(ctx, query, args)
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	return &fakeRows{columns: resp.columns, rows: resp.rows}, nil
+}
+
+type fakeTx struct{ conn *fakeConn }
+
+func (tx *fakeTx) Commit() error { return tx.conn.db.handle(context.Background(), "COMMIT", nil).err }
+func (tx *fakeTx) Rollback() error {
+	return tx.conn.db.handle(context.Background(), "ROLLBACK", nil).err
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,88 @@
+package gobuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GroupByRollup adds a GROUP BY ROLLUP clause producing subtotals from right to left
+// MySQL renders the equivalent "GROUP BY a, b WITH ROLLUP"; SQLite has no ROLLUP and produces an error
+// Example:
+//
+//	builder.Table("sales").Select("region", "city", "SUM(amount) as total").GroupByRollup("region", "city")
+//	// Generates: SELECT region, city, SUM(amount) as total FROM sales GROUP BY ROLLUP (region, city)
+func (gb *GoBuilder) GroupByRollup(columns ...any) *GoBuilder {
+	terms := strings.Join(gb.terms(columns), ", ")
+	switch gb.sqlDialect {
+	case SQLite:
+		gb.err = fmt.Errorf("ROLLUP is not supported in SQLite")
+	case MySQL:
+		gb.groupByClause = fmt.Sprintf("GROUP BY %s WITH ROLLUP", terms)
+	default:
+		gb.groupByClause = fmt.Sprintf("GROUP BY ROLLUP (%s)", terms)
+	}
+	return gb
+}
+
+// GroupByCube adds a GROUP BY CUBE clause producing subtotals for every combination of the columns
+// MySQL and SQLite have no CUBE and produce an error
+func (gb *GoBuilder) GroupByCube(columns ...any) *GoBuilder {
+	if gb.sqlDialect == MySQL || gb.sqlDialect == SQLite {
+		gb.err = fmt.Errorf("CUBE is not supported in %s", gb.sqlDialect)
+		return gb
+	}
+	gb.groupByClause = fmt.Sprintf("GROUP BY CUBE (%s)", strings.Join(gb.terms(columns), ", "))
+	return gb
+}
+
+// GroupByGroupingSets adds a GROUP BY GROUPING SETS clause; an empty set is the grand total
+// MySQL and SQLite have no GROUPING SETS and produce an error
+// Example:
+//
+//	builder.Table("sales").Select().GroupByGroupingSets([][]string{{"region", "city"}, {"region"}, {}})
+//	// Generates: SELECT * FROM sales GROUP BY GROUPING SETS ((region, city), (region), ())
+func (gb *GoBuilder) GroupByGroupingSets(sets [][]string) *GoBuilder {
+	if gb.sqlDialect == MySQL || gb.sqlDialect == SQLite {
+		gb.err = fmt.Errorf("GROUPING SETS is not supported in %s", gb.sqlDialect)
+		return gb
+	}
+	if len(sets) == 0 {
+		gb.err = fmt.Errorf("GROUPING SETS requires at least one set")
+		return gb
+	}
+
+	rendered := make([]string, len(sets))
+	for i, set := range sets {
+		columns := make([]string, len(set))
+		for j, column := range set {
+			columns[j] = sanitizeIdentifier(column)
+		}
+		rendered[i] = fmt.Sprintf("(%s)", strings.Join(columns, ", "))
+	}
+	gb.groupByClause = fmt.Sprintf("GROUP BY GROUPING SETS (%s)", strings.Join(rendered, ", "))
+	return gb
+}
+
+// Grouping returns GROUPING(cols), which tells super-aggregate rows produced by ROLLUP, CUBE or
+// GROUPING SETS apart from regular rows
+// With several columns SQL Server and Oracle render the equivalent GROUPING_ID(cols);
+// SQLite has no GROUPING and produces an error
+func Grouping(columns ...Expr) *Expression {
+	return &Expression{render: func(gb *GoBuilder) string {
+		rendered := make([]string, len(columns))
+		for i, column := range columns {
+			rendered[i] = column.toSQL(gb)
+		}
+
+		name := "GROUPING"
+		switch gb.sqlDialect {
+		case SQLite:
+			gb.err = fmt.Errorf("GROUPING is not supported in SQLite")
+		case SQLServer, Oracle:
+			if len(columns) > 1 {
+				name = "GROUPING_ID"
+			}
+		}
+		return fmt.Sprintf("%s(%s)", name, strings.Join(rendered, ", "))
+	}}
+}
@@ -0,0 +1,109 @@
+package gobuilder
+
+import (
+	"testing"
+)
+
+func TestGrouping(t *testing.T) {
+	tests := []sqlTestCase{
+		{
+			name:    "Rollup Postgres",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("sales").
+					SelectExpr("region", "city", Sum(Col("amount")).As("total"), Grouping(Col("city")).As("is_total")).
+					GroupByRollup("region", "city")
+			},
+			expected: "SELECT region, city, SUM(amount) as total, GROUPING(city) as is_total FROM sales GROUP BY ROLLUP (region, city)",
+			params:   []any{},
+		},
+		{
+			name:    "Rollup MySQL",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("sales").SelectExpr("region", "city", Sum(Col("amount")).As("total")).GroupByRollup("region", "city")
+			},
+			expected: "SELECT region, city, SUM(amount) as total FROM sales GROUP BY region, city WITH ROLLUP",
+			params:   []any{},
+		},
+		{
+			name:    "Cube SQL Server With Grouping Id",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("sales").
+					SelectExpr("region", "product", Grouping(Col("region"), Col("product")).As("level")).
+					GroupByCube(Col("region"), Col("product"))
+			},
+			expected: "SELECT region, product, GROUPING_ID(region, product) as level FROM sales GROUP BY CUBE (region, product)",
+			params:   []any{},
+		},
+		{
+			name:    "Grouping Sets Oracle",
+			dialect: Oracle,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("sales").SelectExpr("region", "city", Count().As("orders")).
+					Where("year", "=", 2024).
+					GroupByGroupingSets([][]string{{"region", "city"}, {"region"}, {}})
+			},
+			expected: "SELECT region, city, COUNT(*) as orders FROM sales WHERE year = :1 GROUP BY GROUPING SETS ((region, city), (region), ())",
+			params:   []any{2024},
+		},
+		{
+			name:    "Having And OrHaving",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").Select("user_id").GroupBy("user_id").
+					Having("COUNT(*) > ?", 5).
+					Having(Sum(Col("total")).Gt(100)).
+					OrHaving("MAX(total) > ?", 1000)
+			},
+			expected: "SELECT user_id FROM orders GROUP BY user_id HAVING COUNT(*) > $1 AND SUM(total) > $2 OR MAX(total) > $3",
+			params:   []any{5, 100, 1000},
+		},
+		{
+			name:    "Having After OrHaving",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("t").Select("a").GroupBy("a").
+					Having("COUNT(*) > ?", 1).
+					OrHaving("SUM(b) > ?", 2).
+					Having("MAX(c) < ?", 3)
+			},
+			expected: "SELECT a FROM t GROUP BY a HAVING (COUNT(*) > $1 OR SUM(b) > $2) AND MAX(c) < $3",
+			params:   []any{1, 2, 3},
+		},
+		{
+			name:    "Having With Condition",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").Select("status").GroupBy("status").Having(Cond().In("status", "paid", "shipped"))
+			},
+			expected: "SELECT status FROM orders GROUP BY status HAVING status IN (?, ?)",
+			params:   []any{"paid", "shipped"},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestGrouping_UnsupportedDialects(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder func() *GoBuilder
+	}{
+		{"Rollup SQLite", func() *GoBuilder { return NewGoBuilder(SQLite).Table("sales").Select().GroupByRollup("region") }},
+		{"Cube MySQL", func() *GoBuilder { return NewGoBuilder(MySQL).Table("sales").Select().GroupByCube("region") }},
+		{"Grouping Sets MySQL", func() *GoBuilder {
+			return NewGoBuilder(MySQL).Table("sales").Select().GroupByGroupingSets([][]string{{"region"}})
+		}},
+		{"Grouping SQLite", func() *GoBuilder { return NewGoBuilder(SQLite).Table("sales").SelectExpr(Grouping(Col("region"))) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.builder().Error() == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
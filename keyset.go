@@ -0,0 +1,244 @@
+package gobuilder
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CursorKey signs the cursors produced by EncodeCursor
+// It defaults to a random key, so cursors only survive for the life of the process;
+// set it to a shared secret when cursors must work across restarts or instances
+var CursorKey = randomCursorKey()
+
+// ErrInvalidCursor is returned for cursors that are malformed or fail signature verification
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrNullCursorValue is returned for cursors holding a NULL value, which a keyset comparison never
+// matches; it wraps ErrInvalidCursor. Sort nullable columns with COALESCE to paginate over them
+var ErrNullCursorValue = fmt.Errorf("%w: NULL value", ErrInvalidCursor)
+
+// cursorValue is a type tagged value inside a cursor
+type cursorValue struct {
+	Type  string `json:"t"`
+	Value any    `json:"v"`
+}
+
+// EncodeCursor returns an opaque, tamper-evident cursor for the ORDER BY values of the last row of a page
+// Supported values are strings, booleans, integers, floats, time.Time and []byte; nil returns ErrNullCursorValue
+// Example:
+//
+//	next, err := EncodeCursor(last.CreatedAt, last.ID)
+func EncodeCursor(values ...any) (string, error) {
+	tagged := make([]cursorValue, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			return "", fmt.Errorf("%w at position %d", ErrNullCursorValue, i+1)
+		case string:
+			tagged[i] = cursorValue{Type: "s", Value: v}
+		case bool:
+			tagged[i] = cursorValue{Type: "b", Value: v}
+		case int, int8, int16, int32, int64:
+			tagged[i] = cursorValue{Type: "i", Value: fmt.Sprint(v)}
+		case uint, uint8, uint16, uint32, uint64:
+			tagged[i] = cursorValue{Type: "u", Value: fmt.Sprint(v)}
+		case float32, float64:
+			tagged[i] = cursorValue{Type: "f", Value: v}
+		case time.Time:
+			tagged[i] = cursorValue{Type: "t", Value: v.Format(time.RFC3339Nano)}
+		case []byte:
+			tagged[i] = cursorValue{Type: "x", Value: base64.RawURLEncoding.EncodeToString(v)}
+		default:
+			return "", fmt.Errorf("unsupported cursor value type %T", value)
+		}
+	}
+
+	payload, err := json.Marshal(tagged)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signCursor(payload)), nil
+}
+
+// DecodeCursor verifies a cursor produced by EncodeCursor and returns its values
+// Integers are returned as int64, unsigned integers as uint64 and floats as float64
+func DecodeCursor(cursor string) ([]any, error) {
+	encoded, signature, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, signCursor(payload)) {
+		return nil, ErrInvalidCursor
+	}
+
+	var tagged []cursorValue
+	if err := json.Unmarshal(payload, &tagged); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	values := make([]any, len(tagged))
+	for i, tv := range tagged {
+		value, err := tv.decode()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// decode converts a tagged value back to its Go type
+func (cv cursorValue) decode() (any, error) {
+	switch cv.Type {
+	case "n":
+		return nil, ErrNullCursorValue
+	case "s", "b":
+		return cv.Value, nil
+	case "f":
+		return cv.Value, nil
+	case "i", "u", "t", "x":
+		s, ok := cv.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("malformed %q value", cv.Type)
+		}
+		switch cv.Type {
+		case "i":
+			var n int64
+			_, err := fmt.Sscan(s, &n)
+			return n, err
+		case "u":
+			var n uint64
+			_, err := fmt.Sscan(s, &n)
+			return n, err
+		case "t":
+			return time.Parse(time.RFC3339Nano, s)
+		default:
+			return base64.RawURLEncoding.DecodeString(s)
+		}
+	}
+	return nil, fmt.Errorf("unknown value type %q", cv.Type)
+}
+
+// signCursor returns the HMAC-SHA256 signature of a cursor payload
+func signCursor(payload []byte) []byte {
+	mac := hmac.New(sha256.New, CursorKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// randomCursorKey generates the default CursorKey
+func randomCursorKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("gobuilder: cannot generate cursor key: %v", err))
+	}
+	return key
+}
+
+// PaginateAfter continues a keyset (cursor) paginated query after the row the cursor points to
+// The optional orderColumns are appended to the ORDER BY terms like OrderBy, so Desc("id") works;
+// the comparison is built from all ORDER BY terms of the builder, which should end with a unique column
+// An empty cursor returns the first page. Combine with Limit to set the page size
+// Parameters:
+//   - cursor: A cursor from EncodeCursor holding the ORDER BY values of the last row, or ""
+//   - orderColumns: Optional ORDER BY terms
+//
+// Returns:
+//   - *GoBuilder: The builder instance for method chaining
+//
+// Example:
+//
+//	builder.Table("posts").Select().PaginateAfter(cursor, "created_at", "id").Limit(0, 20)
+//	// Generates: SELECT * FROM posts WHERE (created_at, id) > ($1, $2) ORDER BY created_at ASC, id ASC OFFSET 0 LIMIT 20
+//
+//	builder.Table("posts").Select().PaginateAfter(cursor, Desc("created_at"), Asc("id"))
+//	// Generates: SELECT * FROM posts WHERE (created_at < $1 OR (created_at = $2 AND id > $3)) ORDER BY created_at DESC, id ASC
+func (gb *GoBuilder) PaginateAfter(cursor string, orderColumns ...any) *GoBuilder {
+	gb.OrderByExpr(orderColumns...)
+	if cursor == "" {
+		return gb
+	}
+
+	if len(gb.orderTerms) == 0 {
+		gb.err = fmt.Errorf("keyset pagination requires ORDER BY terms")
+		return gb
+	}
+	for _, term := range gb.orderTerms {
+		if term.direction == "" || term.nulls != "" {
+			gb.err = fmt.Errorf("keyset pagination does not support ORDER BY %s", gb.orderList([]orderTerm{term}))
+			return gb
+		}
+	}
+
+	values, err := DecodeCursor(cursor)
+	if err != nil {
+		gb.err = err
+		return gb
+	}
+	if len(values) != len(gb.orderTerms) {
+		gb.err = fmt.Errorf("%w: cursor has %d values for %d ORDER BY terms", ErrInvalidCursor, len(values), len(gb.orderTerms))
+		return gb
+	}
+
+	gb.andWhole(gb.keysetClause(values))
+	return gb
+}
+
+// Private method to render the keyset comparison
+// A row value comparison is used when all terms share a direction and the dialect supports it,
+// otherwise the comparison is expanded to (a > ? OR (a = ? AND b > ?))
+func (gb *GoBuilder) keysetClause(values []any) string {
+	terms := gb.orderTerms
+	sameDirection := true
+	for _, term := range terms {
+		sameDirection = sameDirection && term.direction == terms[0].direction
+	}
+
+	rowValues := gb.sqlDialect == Postgres || gb.sqlDialect == MySQL || gb.sqlDialect == SQLite
+	if len(terms) > 1 && sameDirection && rowValues {
+		columns := make([]string, len(terms))
+		placeholders := make([]string, len(terms))
+		for i, term := range terms {
+			columns[i] = term.sql
+			placeholders[i] = gb.addParam(values[i])
+		}
+		return fmt.Sprintf("(%s) %s (%s)",
+			strings.Join(columns, ", "), keysetOperator(terms[0]), strings.Join(placeholders, ", "))
+	}
+
+	branches := make([]string, len(terms))
+	for i := range terms {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("%s = %s", terms[j].sql, gb.addParam(values[j])))
+		}
+		parts = append(parts, fmt.Sprintf("%s %s %s", terms[i].sql, keysetOperator(terms[i]), gb.addParam(values[i])))
+
+		branches[i] = strings.Join(parts, " AND ")
+		if i > 0 {
+			branches[i] = fmt.Sprintf("(%s)", branches[i])
+		}
+	}
+	return fmt.Sprintf("(%s)", strings.Join(branches, " OR "))
+}
+
+// keysetOperator returns the comparison that selects rows after the cursor
+func keysetOperator(term orderTerm) string {
+	if term.direction == "DESC" {
+		return "<"
+	}
+	return ">"
+}
@@ -0,0 +1,155 @@
+package gobuilder
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 30, 0, 123, time.UTC)
+	cursor, err := EncodeCursor(created, 42, "o'brien", 1.5, true, uint(7), []byte{1, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	values, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []any{created, int64(42), "o'brien", 1.5, true, uint64(7), []byte{1, 2}}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected values %v, got %v", expected, values)
+	}
+}
+
+func TestCursor_Tampered(t *testing.T) {
+	cursor, err := EncodeCursor(int64(42))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	forged, err := EncodeCursor(int64(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	payload, _, _ := strings.Cut(forged, ".")
+	_, signature, _ := strings.Cut(cursor, ".")
+
+	for _, c := range []string{payload + "." + signature, "not-a-cursor", cursor + "x", ""} {
+		if _, err := DecodeCursor(c); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("expected ErrInvalidCursor for %q, got %v", c, err)
+		}
+	}
+}
+
+func TestCursor_NullValue(t *testing.T) {
+	if _, err := EncodeCursor(int64(1), nil); !errors.Is(err, ErrNullCursorValue) || !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrNullCursorValue, got %v", err)
+	}
+
+	// A signed cursor holding a NULL value is rejected as well
+	payload := []byte(`[{"t":"n","v":null},{"t":"i","v":"1"}]`)
+	cursor := base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signCursor(payload))
+	gb := NewGoBuilder(Postgres).Table("posts").Select().PaginateAfter(cursor, "archived_at", "id")
+	if err := gb.Error(); !errors.Is(err, ErrNullCursorValue) {
+		t.Errorf("expected ErrNullCursorValue, got %v", err)
+	}
+}
+
+func TestPaginateAfter(t *testing.T) {
+	cursor, err := EncodeCursor("2024-05-01", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []sqlTestCase{
+		{
+			name:    "Row Value Postgres",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("posts").Select().Where("published", "=", true).PaginateAfter(cursor, "created_at", "id")
+			},
+			expected: "SELECT * FROM posts WHERE published = $1 AND (created_at, id) > ($2, $3) ORDER BY created_at ASC, id ASC",
+			params:   []any{true, "2024-05-01", int64(42)},
+		},
+		{
+			name:    "Row Value Descending MySQL",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("posts").Select().OrderByDesc("created_at", "id").PaginateAfter(cursor)
+			},
+			expected: "SELECT * FROM posts WHERE (created_at, id) < (?, ?) ORDER BY created_at DESC, id DESC",
+			params:   []any{"2024-05-01", int64(42)},
+		},
+		{
+			name:    "Expanded On SQL Server",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("posts").Select().PaginateAfter(cursor, "created_at", "id")
+			},
+			expected: "SELECT * FROM posts WHERE (created_at > @1 OR (created_at = @2 AND id > @3)) ORDER BY created_at ASC, id ASC",
+			params:   []any{"2024-05-01", "2024-05-01", int64(42)},
+		},
+		{
+			name:    "Mixed Directions",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("posts").Select().PaginateAfter(cursor, Desc("created_at"), Asc("id"))
+			},
+			expected: "SELECT * FROM posts WHERE (created_at < $1 OR (created_at = $2 AND id > $3)) ORDER BY created_at DESC, id ASC",
+			params:   []any{"2024-05-01", "2024-05-01", int64(42)},
+		},
+		{
+			name:    "Existing OR Conditions",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("posts").Select().Where("a", "=", 1).OrWhere("b", "=", 2).PaginateAfter(cursor, "created_at", "id")
+			},
+			expected: "SELECT * FROM posts WHERE (a = $1 OR b = $2) AND (created_at, id) > ($3, $4) ORDER BY created_at ASC, id ASC",
+			params:   []any{1, 2, "2024-05-01", int64(42)},
+		},
+		{
+			name:    "First Page",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("posts").Select().PaginateAfter("", "created_at", "id")
+			},
+			expected: "SELECT * FROM posts ORDER BY created_at ASC, id ASC",
+			params:   []any{},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestPaginateAfter_Errors(t *testing.T) {
+	twoValues, _ := EncodeCursor(1, 2)
+	oneValue, _ := EncodeCursor(1)
+
+	tests := []struct {
+		name    string
+		builder func() *GoBuilder
+	}{
+		{"Tampered Cursor", func() *GoBuilder { return NewGoBuilder(Postgres).Table("t").Select().PaginateAfter("abc.def", "id") }},
+		{"Value Count Mismatch", func() *GoBuilder { return NewGoBuilder(Postgres).Table("t").Select().PaginateAfter(twoValues, "id") }},
+		{"No Order Terms", func() *GoBuilder { return NewGoBuilder(Postgres).Table("t").Select().PaginateAfter(oneValue) }},
+		{"Nulls Ordering", func() *GoBuilder {
+			return NewGoBuilder(Postgres).Table("t").Select().PaginateAfter(oneValue, Asc("id").NullsLast())
+		}},
+		{"Random Ordering", func() *GoBuilder {
+			return NewGoBuilder(Postgres).Table("t").Select().OrderByRandom().PaginateAfter(oneValue)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.builder().Error() == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package gobuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LockClause is a row locking clause built with ForUpdate, ForShare, ForNoKeyUpdate or ForKeyShare
+type LockClause struct {
+	strength string   // UPDATE, SHARE, NO KEY UPDATE or KEY SHARE
+	wait     string   // NOWAIT, SKIP LOCKED or empty to wait for locked rows
+	tables   []string // The tables to lock, empty for all tables of the query
+}
+
+// ForUpdate locks the selected rows against updates, deletes and other locks
+func ForUpdate() *LockClause {
+	return &LockClause{strength: "UPDATE"}
+}
+
+// ForShare locks the selected rows against updates and deletes, allowing other shared locks
+func ForShare() *LockClause {
+	return &LockClause{strength: "SHARE"}
+}
+
+// ForNoKeyUpdate is a weaker ForUpdate that does not block foreign key checks (PostgreSQL only)
+func ForNoKeyUpdate() *LockClause {
+	return &LockClause{strength: "NO KEY UPDATE"}
+}
+
+// ForKeyShare is a weaker ForShare that only blocks key changes and deletes (PostgreSQL only)
+func ForKeyShare() *LockClause {
+	return &LockClause{strength: "KEY SHARE"}
+}
+
+// NoWait fails the statement instead of waiting when a row is already locked
+func (l *LockClause) NoWait() *LockClause {
+	l.wait = "NOWAIT"
+	return l
+}
+
+// SkipLocked leaves out rows that are already locked instead of waiting for them
+func (l *LockClause) SkipLocked() *LockClause {
+	l.wait = "SKIP LOCKED"
+	return l
+}
+
+// Of restricts the lock to the rows of the given tables (columns on Oracle)
+func (l *LockClause) Of(tables ...string) *LockClause {
+	l.tables = append(l.tables, tables...)
+	return l
+}
+
+// Lock adds a row locking clause at the end of the statement
+// A string is rendered as it is; a *LockClause is rendered for the dialect, as table hints on
+// SQL Server, and sets an error on dialects that do not support it, such as SQLite
+// Parameters:
+//   - lock: A *LockClause from ForUpdate, ForShare, ForNoKeyUpdate or ForKeyShare, or a raw clause
+//
+// Returns:
+//   - *GoBuilder: The builder instance for method chaining
+//
+// Example:
+//
+//	builder.Table("jobs").Select().Where("status", "=", "queued").OrderBy("id").Limit(0, 10).Lock(ForUpdate().SkipLocked())
+//	// PostgreSQL: SELECT * FROM jobs WHERE status = $1 ORDER BY id ASC OFFSET 0 LIMIT 10 FOR UPDATE SKIP LOCKED
+//	// SQL Server: SELECT * FROM jobs WITH (UPDLOCK, ROWLOCK, READPAST) WHERE status = @1 ORDER BY id ASC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY
+func (gb *GoBuilder) Lock(lock any) *GoBuilder {
+	switch l := lock.(type) {
+	case string:
+		gb.lockClause = l
+	case *LockClause:
+		if gb.sqlDialect == SQLServer {
+			gb.tableHints = gb.lockHints(l)
+		} else {
+			gb.lockClause = gb.lockSQL(l)
+		}
+	default:
+		gb.err = fmt.Errorf("unsupported lock type %T", lock)
+	}
+	gb.checkLock()
+	return gb
+}
+
+// Private method to set an error when the statement cannot carry its row lock
+// Oracle rejects FOR UPDATE together with a row limit (ORA-02014), and SQL Server table hints
+// are only rendered on SELECT statements
+func (gb *GoBuilder) checkLock() {
+	switch {
+	case gb.sqlDialect == Oracle && gb.lockClause != "" && gb.limitValues != nil:
+		gb.err = fmt.Errorf("row locking with a row limit is not supported in %s", gb.sqlDialect)
+	case gb.sqlDialect == SQLServer && gb.tableHints != "" && gb.selectClause != "" && !strings.HasPrefix(gb.selectClause, "SELECT "):
+		gb.err = fmt.Errorf("row locking of statements other than SELECT is not supported in %s", gb.sqlDialect)
+	}
+}
+
+// Private method to render a lock clause as FOR ... [OF ...] [NOWAIT | SKIP LOCKED]
+func (gb *GoBuilder) lockSQL(l *LockClause) string {
+	switch gb.sqlDialect {
+	case SQLite:
+		gb.err = fmt.Errorf("row locking is not supported in %s", gb.sqlDialect)
+		return ""
+	case MySQL:
+		if l.strength != "UPDATE" && l.strength != "SHARE" {
+			gb.err = fmt.Errorf("FOR %s is not supported in %s", l.strength, gb.sqlDialect)
+			return ""
+		}
+	case Oracle:
+		if l.strength != "UPDATE" {
+			gb.err = fmt.Errorf("FOR %s is not supported in %s", l.strength, gb.sqlDialect)
+			return ""
+		}
+	}
+
+	clause := "FOR " + l.strength
+	if len(l.tables) > 0 {
+		clause += " OF " + strings.Join(l.tables, ", ")
+	}
+	if l.wait != "" {
+		clause += " " + l.wait
+	}
+	return clause
+}
+
+// Private method to render a lock clause as SQL Server table hints
+func (gb *GoBuilder) lockHints(l *LockClause) string {
+	hints := make([]string, 0, 3)
+	switch l.strength {
+	case "UPDATE":
+		hints = append(hints, "UPDLOCK", "ROWLOCK")
+	case "SHARE":
+		hints = append(hints, "HOLDLOCK", "ROWLOCK")
+	default:
+		gb.err = fmt.Errorf("FOR %s is not supported in %s", l.strength, gb.sqlDialect)
+		return ""
+	}
+	if len(l.tables) > 0 {
+		gb.err = fmt.Errorf("locking specific tables is not supported in %s", gb.sqlDialect)
+		return ""
+	}
+
+	switch l.wait {
+	case "NOWAIT":
+		hints = append(hints, "NOWAIT")
+	case "SKIP LOCKED":
+		hints = append(hints, "READPAST")
+	}
+	return fmt.Sprintf("WITH (%s)", strings.Join(hints, ", "))
+}
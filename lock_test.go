@@ -0,0 +1,128 @@
+package gobuilder
+
+import (
+	"testing"
+)
+
+func TestLock(t *testing.T) {
+	tests := []sqlTestCase{
+		{
+			name:    "Postgres Skip Locked After Limit",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("jobs").Select().Lock(ForUpdate().SkipLocked()).Where("status", "=", "queued").
+					OrderBy("id").Limit(0, 10)
+			},
+			expected: "SELECT * FROM jobs WHERE status = $1 ORDER BY id ASC OFFSET 0 LIMIT 10 FOR UPDATE SKIP LOCKED",
+			params:   []any{"queued"},
+		},
+		{
+			name:    "Postgres Key Strengths",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("accounts").Select().Join("users", "users.id", "=", "accounts.user_id").
+					Where("accounts.id", "=", 7).Lock(ForNoKeyUpdate().Of("accounts").NoWait())
+			},
+			expected: "SELECT * FROM accounts INNER JOIN users ON users.id = accounts.user_id WHERE accounts.id = $1 FOR NO KEY UPDATE OF accounts NOWAIT",
+			params:   []any{7},
+		},
+		{
+			name:    "MySQL Share",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").Select("id").Where("id", "=", 1).Lock(ForShare())
+			},
+			expected: "SELECT id FROM users WHERE id = ? FOR SHARE",
+			params:   []any{1},
+		},
+		{
+			name:    "Oracle Update Of Columns",
+			dialect: Oracle,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").Select().Where("id", "=", 1).Lock(ForUpdate().Of("users.name").NoWait())
+			},
+			expected: "SELECT * FROM users WHERE id = :1 FOR UPDATE OF users.name NOWAIT",
+			params:   []any{1},
+		},
+		{
+			name:    "SQL Server Table Hints",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("jobs").Select("id").Join("queues", "queues.id", "=", "jobs.queue_id").
+					Where("status", "=", "queued").Lock(ForUpdate().SkipLocked())
+			},
+			expected: "SELECT id FROM jobs WITH (UPDLOCK, ROWLOCK, READPAST) INNER JOIN queues ON queues.id = jobs.queue_id WHERE status = @1",
+			params:   []any{"queued"},
+		},
+		{
+			name:    "Raw String",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").Select().Lock("FOR UPDATE").Where("id", "=", 1)
+			},
+			expected: "SELECT * FROM users WHERE id = $1 FOR UPDATE",
+			params:   []any{1},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestLock_Unsupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect SQLDialect
+		lock    any
+	}{
+		{"SQLite", SQLite, ForUpdate()},
+		{"MySQL Key Share", MySQL, ForKeyShare()},
+		{"Oracle Share", Oracle, ForShare()},
+		{"SQL Server No Key Update", SQLServer, ForNoKeyUpdate()},
+		{"SQL Server Of", SQLServer, ForUpdate().Of("users")},
+		{"Unknown Type", Postgres, 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewGoBuilder(tt.dialect).Table("users").Select().Lock(tt.lock).Error(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLock_UnsupportedStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder func() *GoBuilder
+	}{
+		{"Oracle Limit Then Lock", func() *GoBuilder {
+			return NewGoBuilder(Oracle).Table("jobs").Select().Limit(0, 10).Lock(ForUpdate())
+		}},
+		{"Oracle Lock Then Limit", func() *GoBuilder {
+			return NewGoBuilder(Oracle).Table("jobs").Select().Lock(ForUpdate().SkipLocked()).Limit(0, 10)
+		}},
+		{"SQL Server Update", func() *GoBuilder {
+			return NewGoBuilder(SQLServer).Table("jobs").Update(map[string]any{"status": "done"}).Lock(ForUpdate())
+		}},
+		{"SQL Server Lock Then Delete", func() *GoBuilder {
+			return NewGoBuilder(SQLServer).Table("jobs").Lock(ForUpdate()).Delete()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.builder().Error(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLock_DroppedByCount(t *testing.T) {
+	query, _ := NewGoBuilder(Postgres).Table("jobs").Select().Where("status", "=", "queued").
+		Lock(ForUpdate().SkipLocked()).ToCount().Prepare()
+	if expected := "SELECT COUNT(*) FROM jobs WHERE status = $1"; query != expected {
+		t.Errorf("expected query %v, got %v", expected, query)
+	}
+}
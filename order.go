@@ -0,0 +1,113 @@
+package gobuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OrderTerm is an ORDER BY term with its own direction and NULLS ordering, built with Asc or Desc
+type OrderTerm struct {
+	term  any    // Column name or expression
+	desc  bool   // Whether the term sorts descending
+	nulls string // FIRST, LAST or empty for the dialect default
+}
+
+// Asc returns an ascending ORDER BY term for a column name or expression
+func Asc(term any) *OrderTerm {
+	return &OrderTerm{term: term}
+}
+
+// Desc returns a descending ORDER BY term for a column name or expression
+func Desc(term any) *OrderTerm {
+	return &OrderTerm{term: term, desc: true}
+}
+
+// NullsFirst returns a copy of the term sorting NULL values before all other values
+func (o *OrderTerm) NullsFirst() *OrderTerm {
+	copied := *o
+	copied.nulls = "FIRST"
+	return &copied
+}
+
+// NullsLast returns a copy of the term sorting NULL values after all other values
+func (o *OrderTerm) NullsLast() *OrderTerm {
+	copied := *o
+	copied.nulls = "LAST"
+	return &copied
+}
+
+// orderTerm is a rendered ORDER BY term stored on the builder
+type orderTerm struct {
+	sql       string // The rendered column or expression
+	direction string // ASC, DESC, or empty for terms without a direction such as random ordering
+	nulls     string // FIRST, LAST or empty
+}
+
+// OrderByRandom adds a random ordering term using the dialect's random function
+func (gb *GoBuilder) OrderByRandom() *GoBuilder {
+	var random string
+	switch gb.sqlDialect {
+	case MySQL:
+		random = "RAND()"
+	case SQLServer:
+		random = "NEWID()"
+	case Oracle:
+		random = "DBMS_RANDOM.VALUE"
+	default:
+		random = "RANDOM()"
+	}
+	gb.orderTerms = append(gb.orderTerms, orderTerm{sql: random})
+	return gb
+}
+
+// Private method to append ORDER BY terms; desc is the direction of terms not built with Asc or Desc
+func (gb *GoBuilder) addOrder(desc bool, columns []any) *GoBuilder {
+	for _, column := range columns {
+		if term, ok := gb.orderTerm(column, desc); ok {
+			gb.orderTerms = append(gb.orderTerms, term)
+		}
+	}
+	return gb
+}
+
+// Private method to render a column name, expression or *OrderTerm as an order term
+func (gb *GoBuilder) orderTerm(column any, desc bool) (orderTerm, bool) {
+	nulls := ""
+	if o, ok := column.(*OrderTerm); ok {
+		column, desc, nulls = o.term, o.desc, o.nulls
+	}
+
+	rendered := gb.terms([]any{column})
+	if len(rendered) == 0 {
+		return orderTerm{}, false
+	}
+
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+	return orderTerm{sql: rendered[0], direction: direction, nulls: nulls}, true
+}
+
+// Private method to render order terms as a comma separated list
+// MySQL and SQL Server have no NULLS FIRST/LAST, so a CASE term sorting NULL values is placed in front
+func (gb *GoBuilder) orderList(terms []orderTerm) string {
+	rendered := make([]string, 0, len(terms))
+	for _, term := range terms {
+		sql := strings.TrimSpace(fmt.Sprintf("%s %s", term.sql, term.direction))
+		if term.nulls != "" {
+			switch gb.sqlDialect {
+			case MySQL, SQLServer:
+				first, rest := 1, 0
+				if term.nulls == "FIRST" {
+					first, rest = 0, 1
+				}
+				rendered = append(rendered, fmt.Sprintf("CASE WHEN %s IS NULL THEN %d ELSE %d END", term.sql, first, rest))
+			default:
+				sql = fmt.Sprintf("%s NULLS %s", sql, term.nulls)
+			}
+		}
+		rendered = append(rendered, sql)
+	}
+	return strings.Join(rendered, ", ")
+}
@@ -0,0 +1,96 @@
+package gobuilder
+
+import (
+	"testing"
+)
+
+func TestOrder(t *testing.T) {
+	tests := []sqlTestCase{
+		{
+			name:    "Mixed Directions",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").Select().OrderBy("last_name").OrderByDesc("created_at").OrderByExpr(Desc("score"), "id")
+			},
+			expected: "SELECT * FROM users ORDER BY last_name ASC, created_at DESC, score DESC, id ASC",
+			params:   []any{},
+		},
+		{
+			name:    "Nulls Postgres",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("tasks").Select().OrderByExpr(Asc("due_at").NullsLast(), Desc("priority").NullsFirst())
+			},
+			expected: "SELECT * FROM tasks ORDER BY due_at ASC NULLS LAST, priority DESC NULLS FIRST",
+			params:   []any{},
+		},
+		{
+			name:    "Nulls Emulated On MySQL",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("tasks").Select().OrderByExpr(Asc("due_at").NullsLast(), Desc("priority").NullsFirst())
+			},
+			expected: "SELECT * FROM tasks ORDER BY CASE WHEN due_at IS NULL THEN 1 ELSE 0 END, due_at ASC, CASE WHEN priority IS NULL THEN 0 ELSE 1 END, priority DESC",
+			params:   []any{},
+		},
+		{
+			name:    "Nulls Emulated On SQL Server",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("tasks").Select().OrderByExpr(Desc("due_at").NullsLast())
+			},
+			expected: "SELECT * FROM tasks ORDER BY CASE WHEN due_at IS NULL THEN 1 ELSE 0 END, due_at DESC",
+			params:   []any{},
+		},
+		{
+			name:    "Reused Term",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				o := Asc("x")
+				return gb.Table("tasks").Select().OrderByExpr(o, o.NullsLast())
+			},
+			expected: "SELECT * FROM tasks ORDER BY x ASC, x ASC NULLS LAST",
+			params:   []any{},
+		},
+		{
+			name:    "Expression With Bound Parameters",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("products").Select().Where("active", "=", true).
+					OrderByExpr(Desc(Func("ABS", Col("price").Sub(100))), Asc(Case(Col("tier")).When("gold", 0).Else(1)))
+			},
+			expected: "SELECT * FROM products WHERE active = $1 ORDER BY ABS((price - $2)) DESC, CASE tier WHEN $3 THEN $4 ELSE $5 END ASC",
+			params:   []any{true, 100, "gold", 0, 1},
+		},
+		{
+			name:    "Nulls Emulation Repeats Parameters",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("products").Select().OrderByExpr(Asc(Coalesce(Col("sale_price"), 0)).NullsFirst())
+			},
+			expected: "SELECT * FROM products ORDER BY CASE WHEN COALESCE(sale_price, ?) IS NULL THEN 0 ELSE 1 END, COALESCE(sale_price, ?) ASC",
+			params:   []any{0, 0},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestOrder_Random(t *testing.T) {
+	expected := map[SQLDialect]string{
+		Postgres:  "SELECT * FROM quotes ORDER BY RANDOM()",
+		MySQL:     "SELECT * FROM quotes ORDER BY RAND()",
+		SQLite:    "SELECT * FROM quotes ORDER BY RANDOM()",
+		SQLServer: "SELECT * FROM quotes ORDER BY NEWID()",
+		Oracle:    "SELECT * FROM quotes ORDER BY DBMS_RANDOM.VALUE",
+	}
+
+	for dialect, want := range expected {
+		t.Run(string(dialect), func(t *testing.T) {
+			query, _ := NewGoBuilder(dialect).Table("quotes").Select().OrderByRandom().Prepare()
+			if query != want {
+				t.Errorf("expected query %v, got %v", want, query)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package gobuilder
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// windowTotalColumn is the column added to the page query by WithWindowCount
+const windowTotalColumn = "gobuilder_total"
+
+// Page is one page of results returned by Paginate
+type Page struct {
+	Items    []map[string]any // The rows of the page
+	Total    int64            // The number of rows across all pages
+	Page     int              // The current page, starting at 1
+	PerPage  int              // The page size
+	LastPage int              // The last page, at least 1
+	HasMore  bool             // Whether pages follow the current one
+}
+
+// PaginateOption configures Paginate
+type PaginateOption func(*paginateOptions)
+
+// paginateOptions holds the settings applied by PaginateOption values
+type paginateOptions struct {
+	windowCount bool
+}
+
+// WithWindowCount reads the total from a COUNT(*) OVER () column of the page query instead of
+// running a separate count query. Distinct and set operation queries, and pages past the end,
+// still use the count query
+func WithWindowCount() PaginateOption {
+	return func(o *paginateOptions) {
+		o.windowCount = true
+	}
+}
+
+// Paginate runs the query for one page and a count query derived from the same builder
+// The count query drops ORDER BY and LIMIT, and wraps grouped, distinct and set operation queries
+// in a subquery. Both queries run like Query, with its timeout and error translation.
+// Like Prepare, it resets the builder
+// Parameters:
+//   - ctx: Context for both queries
+//   - ex: Executor such as *sql.DB or *sql.Tx
+//   - page: Page number, starting at 1
+//   - perPage: Page size
+//   - opts: Options such as WithWindowCount()
+//
+// Returns:
+//   - *Page: The items and page metadata
+//   - error: Any build or database error
+//
+// Example:
+//
+//	page, err := builder.Table("users").Select().Where("active", "=", true).OrderBy("id").Paginate(ctx, db, 2, 20)
+//	// Runs: SELECT COUNT(*) FROM users WHERE active = $1
+//	// Runs: SELECT * FROM users WHERE active = $1 ORDER BY id ASC OFFSET 20 LIMIT 20
+func (gb *GoBuilder) Paginate(ctx context.Context, ex Executor, page, perPage int, opts ...PaginateOption) (*Page, error) {
+	defer gb.reset()
+
+	if gb.err != nil {
+		return nil, gb.err
+	}
+	if page < 1 || perPage < 1 {
+		return nil, fmt.Errorf("invalid page %d or page size %d", page, perPage)
+	}
+
+	ctx, cancel := gb.timeoutContext(ctx)
+	defer cancel()
+
+	options := &paginateOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	query := gb.Clone()
+	windowed := options.windowCount && !gb.needsWrapping() && query.addWindowTotal()
+	query.Limit((page-1)*perPage, perPage)
+
+	items, err := query.Query(ctx, ex)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64 = -1
+	if windowed && len(items) > 0 {
+		total, err = toInt64(items[0][windowTotalColumn])
+		if err != nil {
+			return nil, err
+		}
+	}
+	if windowed {
+		for _, item := range items {
+			delete(item, windowTotalColumn)
+		}
+	}
+	if total < 0 {
+		// The count runs like the page query, with the same timeout and error translation
+		counted, err := gb.countBuilder().Query(ctx, ex)
+		if err != nil {
+			return nil, err
+		}
+		if len(counted) != 1 || len(counted[0]) != 1 {
+			return nil, fmt.Errorf("unexpected count result %v", counted)
+		}
+		for _, value := range counted[0] {
+			if total, err = toInt64(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	lastPage := int((total + int64(perPage) - 1) / int64(perPage))
+	if lastPage < 1 {
+		lastPage = 1
+	}
+	return &Page{
+		Items:    items,
+		Total:    total,
+		Page:     page,
+		PerPage:  perPage,
+		LastPage: lastPage,
+		HasMore:  page < lastPage,
+	}, nil
+}
+
+// Private method to derive a COUNT(*) query from a SELECT, without ORDER BY and LIMIT
+func (gb *GoBuilder) countBuilder() *GoBuilder {
+	return gb.rewriteSelect(func(*GoBuilder) string { return "COUNT(*)" }, false, "count_query")
+}
+
+// Private method to derive a query selecting a new column list from the current SELECT
+// The list replaces the select list directly, or, for grouped, distinct and set operation queries,
+// selects from the original query wrapped in a derived table with the given alias.
+// ORDER BY and LIMIT are dropped unless keepOrder is set, in which case they apply to the outer query;
+// ORDER BY terms that are not selected are then added to the derived table as gobuilder_order_n columns
+func (gb *GoBuilder) rewriteSelect(columns func(*GoBuilder) string, keepOrder bool, alias string) *GoBuilder {
+	derived := gb.Clone()
+	derived.err = gb.err
+	order, limit := derived.orderTerms, derived.limitValues
+	derived.orderTerms, derived.limitValues = nil, nil
+	// Aggregates and derived tables cannot lock rows
+	derived.lockClause, derived.tableHints = "", ""
+	if !keepOrder {
+		order, limit = nil, nil
+	}
+
+	if !gb.needsWrapping() {
+		derived.selectClause = fmt.Sprintf("SELECT %s FROM %s", columns(derived), derived.tableClause)
+		derived.orderTerms, derived.limitValues = order, limit
+		return derived
+	}
+
+	// CTEs and the MySQL timeout hint stay on the outer query, the rest becomes a derived table
+	ctes, recursive := derived.cteClauses, derived.recursiveCTE
+	derived.cteClauses, derived.recursiveCTE, derived.timeout = nil, false, 0
+	// Set operations and SELECT DISTINCT can only be ordered by their selected columns
+	from := " FROM " + derived.tableClause
+	if len(order) > 0 && gb.unionClause == "" && strings.HasPrefix(derived.selectClause, "SELECT ") &&
+		!strings.HasPrefix(derived.selectClause, "SELECT DISTINCT") && strings.HasSuffix(derived.selectClause, from) {
+		var extra []string
+		extra, order = derived.carryOrder(order)
+		list := strings.TrimSuffix(derived.selectClause, from)
+		derived.selectClause = strings.Join(append([]string{list}, extra...), ", ") + from
+		derived.selectColumns = append(slices.Clip(derived.selectColumns), extra...)
+	}
+	var inner string
+	if len(derived.distinctOn) > 0 {
+		// The ORDER BY terms still pick the row kept for each DISTINCT ON group
+		derived.orderTerms = gb.orderTerms
+		inner = derived.buildDistinctOn(false)
+	} else {
+		inner = derived.build()
+	}
+
+	wrapped := NewGoBuilder(gb.sqlDialect)
+	wrapped.err = gb.err
+	wrapped.timeout = gb.timeout
+	wrapped.cteClauses, wrapped.recursiveCTE = ctes, recursive
+	wrapped.paramsClause = derived.paramsClause
+	if gb.sqlDialect != Oracle {
+		alias = "AS " + alias
+	}
+	wrapped.tableClause = fmt.Sprintf("(%s) %s", inner, alias)
+	wrapped.selectClause = fmt.Sprintf("SELECT %s FROM %s", columns(wrapped), wrapped.tableClause)
+	wrapped.orderTerms, wrapped.limitValues = order, limit
+	return wrapped
+}
+
+// Private method to tell whether the select list cannot simply be replaced
+func (gb *GoBuilder) needsWrapping() bool {
+	return gb.groupByClause != "" || gb.havingClause != "" || gb.unionClause != "" ||
+		len(gb.windowClauses) > 0 || len(gb.distinctOn) > 0 ||
+		!strings.HasPrefix(gb.selectClause, "SELECT ") ||
+		strings.HasPrefix(gb.selectClause, "SELECT DISTINCT") ||
+		strings.HasPrefix(gb.selectClause, "SELECT TOP") ||
+		!strings.HasSuffix(gb.selectClause, " FROM "+gb.tableClause)
+}
+
+// Private method to add the COUNT(*) OVER () column used by WithWindowCount
+func (gb *GoBuilder) addWindowTotal() bool {
+	from := " FROM " + gb.tableClause
+	if !strings.HasSuffix(gb.selectClause, from) {
+		return false
+	}
+	columns := strings.TrimSuffix(gb.selectClause, from)
+	// Oracle does not accept an unqualified * next to other columns
+	if gb.sqlDialect == Oracle && columns == "SELECT *" {
+		return false
+	}
+	gb.selectClause = fmt.Sprintf("%s, COUNT(*) OVER () as %s%s", columns, windowTotalColumn, from)
+	return true
+}
+
+// toInt64 converts the integer types returned by drivers for COUNT(*)
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int32:
+		return int64(v), nil
+	case int:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case string:
+		var n int64
+		_, err := fmt.Sscan(v, &n)
+		return n, err
+	}
+	return 0, fmt.Errorf("unexpected count value %v (%T)", value, value)
+}
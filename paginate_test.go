@@ -0,0 +1,192 @@
+package gobuilder
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCountBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  SQLDialect
+		builder  func(gb *GoBuilder) *GoBuilder
+		expected string
+		params   []any
+	}{
+		{
+			name:    "Replaces Select List",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("users").Select("id", "name").Join("roles", "roles.user_id", "=", "users.id").
+					Where("active", "=", true).OrderBy("name").Limit(20, 10)
+			},
+			expected: "SELECT COUNT(*) FROM users INNER JOIN roles ON roles.user_id = users.id WHERE active = $1",
+			params:   []any{true},
+		},
+		{
+			name:    "Wraps Grouped Query",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").Select("user_id", "SUM(total) as total").Where("status", "=", "paid").
+					GroupBy("user_id").Having("SUM(total) > ?", 100).OrderByDesc("total")
+			},
+			expected: "SELECT COUNT(*) FROM (SELECT user_id, SUM(total) as total FROM orders WHERE status = ? GROUP BY user_id HAVING SUM(total) > ?) AS count_query",
+			params:   []any{"paid", 100},
+		},
+		{
+			name:    "Wraps Distinct Query On Oracle",
+			dialect: Oracle,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").SelectDistinct("user_id").Where("status", "=", "paid")
+			},
+			expected: "SELECT COUNT(*) FROM (SELECT DISTINCT user_id FROM orders WHERE status = :1) count_query",
+			params:   []any{"paid"},
+		},
+		{
+			name:    "Keeps CTEs In Front",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				recent := NewGoBuilder(Postgres).Table("orders").Select("user_id").Where("created_at", ">", "2024-01-01")
+				return gb.With("recent", recent).Table("recent").Select("user_id").GroupBy("user_id").Where("user_id", ">", 5)
+			},
+			expected: "WITH recent AS (SELECT user_id FROM orders WHERE created_at > $1) SELECT COUNT(*) FROM (SELECT user_id FROM recent WHERE user_id > $2 GROUP BY user_id) AS count_query",
+			params:   []any{"2024-01-01", 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, params := tt.builder(NewGoBuilder(tt.dialect)).countBuilder().Prepare()
+			if query != tt.expected {
+				t.Errorf("expected query %v, got %v", tt.expected, query)
+			}
+			if !reflect.DeepEqual(params, tt.params) {
+				t.Errorf("expected params %v, got %v", tt.params, params)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) fakeResponse {
+		if strings.HasPrefix(query, "SELECT COUNT(*)") {
+			return fakeResponse{columns: []string{"count"}, rows: [][]driver.Value{{int64(45)}}}
+		}
+		return fakeResponse{
+			columns: []string{"id", "name"},
+			rows:    [][]driver.Value{{int64(21), []byte("ada")}, {int64(22), "grace"}},
+		}
+	})
+	defer db.Close()
+
+	page, err := NewGoBuilder(Postgres).Table("users").Select("id", "name").Where("active", "=", true).OrderBy("id").
+		Paginate(context.Background(), db, 2, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedQueries := []string{
+		"SELECT id, name FROM users WHERE active = $1 ORDER BY id ASC OFFSET 20 LIMIT 20",
+		"SELECT COUNT(*) FROM users WHERE active = $1",
+	}
+	if !reflect.DeepEqual(fake.queries(), expectedQueries) {
+		t.Errorf("expected queries %v, got %v", expectedQueries, fake.queries())
+	}
+	if !reflect.DeepEqual(fake.args(1), []any{true}) {
+		t.Errorf("expected count params %v, got %v", []any{true}, fake.args(1))
+	}
+
+	expected := &Page{
+		Items:    []map[string]any{{"id": int64(21), "name": "ada"}, {"id": int64(22), "name": "grace"}},
+		Total:    45,
+		Page:     2,
+		PerPage:  20,
+		LastPage: 3,
+		HasMore:  true,
+	}
+	if !reflect.DeepEqual(page, expected) {
+		t.Errorf("expected page %+v, got %+v", expected, page)
+	}
+}
+
+func TestPaginate_WindowCount(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) fakeResponse {
+		if strings.HasPrefix(query, "SELECT COUNT(*)") {
+			return fakeResponse{columns: []string{"count"}, rows: [][]driver.Value{{int64(4)}}}
+		}
+		if len(args) > 0 && args[0] == "empty" {
+			return fakeResponse{columns: []string{"id", windowTotalColumn}}
+		}
+		return fakeResponse{
+			columns: []string{"id", windowTotalColumn},
+			rows:    [][]driver.Value{{int64(1), int64(4)}, {int64(2), int64(4)}},
+		}
+	})
+	defer db.Close()
+
+	page, err := NewGoBuilder(MySQL).Table("users").Select("id").Where("team", "=", "core").
+		Paginate(context.Background(), db, 1, 2, WithWindowCount())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedQueries := []string{"SELECT id, COUNT(*) OVER () as gobuilder_total FROM users WHERE team = ? LIMIT 2 OFFSET 0"}
+	if !reflect.DeepEqual(fake.queries(), expectedQueries) {
+		t.Errorf("expected queries %v, got %v", expectedQueries, fake.queries())
+	}
+	if page.Total != 4 || page.LastPage != 2 || !page.HasMore {
+		t.Errorf("unexpected page metadata %+v", page)
+	}
+	if !reflect.DeepEqual(page.Items, []map[string]any{{"id": int64(1)}, {"id": int64(2)}}) {
+		t.Errorf("expected total column to be removed, got %v", page.Items)
+	}
+
+	// A page past the end has no rows to read the total from, so the count query runs
+	page, err = NewGoBuilder(MySQL).Table("users").Select("id").Where("team", "=", "empty").
+		Paginate(context.Background(), db, 9, 2, WithWindowCount())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.Total != 4 || page.HasMore || len(page.Items) != 0 {
+		t.Errorf("unexpected page %+v", page)
+	}
+}
+
+func TestPaginate_CountQuery(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) fakeResponse {
+		if strings.HasPrefix(query, "SELECT COUNT(*)") {
+			return fakeResponse{err: &pgError{code: "23505"}}
+		}
+		return fakeResponse{columns: []string{"id"}}
+	})
+	defer db.Close()
+
+	start := time.Now()
+	_, err := NewGoBuilder(Postgres).Table("users").Select("id").WithTimeout(time.Second).
+		Paginate(context.Background(), db, 3, 10)
+	var dbErr *DBError
+	if !errors.As(err, &dbErr) || !errors.Is(err, ErrUniqueViolation) {
+		t.Fatalf("expected *DBError from the count query, got %v", err)
+	}
+
+	if queries := fake.queries(); len(queries) != 2 || !strings.HasPrefix(queries[1], "SELECT COUNT(*)") {
+		t.Fatalf("expected the page and count queries, got %v", queries)
+	}
+	if d := fake.deadline(1).Sub(start); d < time.Second/2 || d > 2*time.Second {
+		t.Errorf("expected a 1s timeout on the count query, got %v", d)
+	}
+}
+
+func TestPaginate_InvalidPage(t *testing.T) {
+	db, _ := newFakeDB(nil)
+	defer db.Close()
+
+	if _, err := NewGoBuilder(Postgres).Table("users").Select().Paginate(context.Background(), db, 0, 10); err == nil {
+		t.Error("expected error for page 0")
+	}
+}
@@ -0,0 +1,181 @@
+package gobuilder
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"reflect"
+	"time"
+)
+
+// RetryPolicy retries work that failed with a transient error, waiting with exponential backoff
+// The zero value makes 3 attempts, waiting about 10ms and then 20ms, and retries the errors
+// IsRetryable reports for the dialect
+type RetryPolicy struct {
+	MaxAttempts int                  // The number of attempts including the first, 3 when zero
+	BaseDelay   time.Duration        // The wait before the first retry, doubled for every further retry, 10ms when zero
+	MaxDelay    time.Duration        // The upper bound of a single wait, 1s when zero
+	Retryable   func(err error) bool // Decides which errors are retried, IsRetryable for the dialect when nil
+}
+
+// Do runs fn until it succeeds, fails with an error that is not retryable, runs out of attempts
+// or ctx is done, and returns the last error
+// Example:
+//
+//	policy := &RetryPolicy{MaxAttempts: 5}
+//	err := policy.Do(ctx, Postgres, func() error {
+//	    _, err := NewGoBuilder(Postgres).Table("counters").Increment("hits", 1).Where("id", "=", 1).Exec(ctx, db)
+//	    return err
+//	})
+func (p *RetryPolicy) Do(ctx context.Context, dialect SQLDialect, fn func() error) error {
+	attempts, delay, maxDelay := p.MaxAttempts, p.BaseDelay, p.MaxDelay
+	if attempts < 1 {
+		attempts = 3
+	}
+	if delay <= 0 {
+		delay = 10 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = time.Second
+	}
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = func(err error) bool { return IsRetryable(dialect, err) }
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= attempts || !retryable(err) {
+			return err
+		}
+
+		// Half of the delay is random, so workers that failed together do not retry together
+		wait := min(delay, maxDelay)
+		wait = wait/2 + rand.N(wait/2+1)
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+// IsRetryable reports whether err is a transient error of the dialect that succeeds when the
+// work is run again: a serialization failure or deadlock (PostgreSQL 40001 and 40P01, MySQL 1213
+// and 1205, SQL Server 1205) or a busy database (SQLite SQLITE_BUSY)
+// Drivers are recognised by their SQLState(), SQLErrorNumber() or Code() methods, or by their SQLState,
+// Number and Code fields; with an empty dialect the codes of every dialect are checked
+func IsRetryable(dialect SQLDialect, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if dialect == "" || dialect == Postgres {
+		if state := errorSQLState(err); state == "40001" || state == "40P01" {
+			return true
+		}
+	}
+
+	number, ok := errorNumber(err)
+	if !ok {
+		return false
+	}
+	switch dialect {
+	case MySQL:
+		return number == 1213 || number == 1205
+	case SQLServer:
+		return number == 1205
+	case SQLite:
+		return number&0xff == 5
+	case "":
+		return number == 1213 || number == 1205 || (number < 1000 && number&0xff == 5)
+	}
+	return false
+}
+
+// errorSQLState returns the SQLSTATE code of a driver error, from a SQLState() method
+// or from a SQLState or Code field, and an empty string when there is none
+func errorSQLState(err error) string {
+	var state interface{ SQLState() string }
+	if errors.As(err, &state) {
+		return state.SQLState()
+	}
+
+	field, ok := errorField(err, "SQLState", "Code")
+	if !ok {
+		return ""
+	}
+	switch {
+	case field.Kind() == reflect.String:
+		return field.String()
+	case field.Kind() == reflect.Array && field.Type().Elem().Kind() == reflect.Uint8:
+		state := make([]byte, field.Len())
+		for i := range state {
+			state[i] = byte(field.Index(i).Uint())
+		}
+		return string(state)
+	}
+	return ""
+}
+
+// errorNumber returns the numeric code of a driver error, from a SQLErrorNumber() or Code() method
+// or from a Number or Code field
+func errorNumber(err error) (int64, bool) {
+	var mssql interface{ SQLErrorNumber() int32 }
+	if errors.As(err, &mssql) {
+		return int64(mssql.SQLErrorNumber()), true
+	}
+	var coded interface{ Code() int }
+	if errors.As(err, &coded) {
+		return int64(coded.Code()), true
+	}
+
+	field, ok := errorField(err, "Number", "Code")
+	if !ok {
+		return 0, false
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(field.Uint()), true
+	}
+	return 0, false
+}
+
+// errorField returns the first of the named fields found on an error struct in the tree of err,
+// following both Unwrap() error and Unwrap() []error like errors.As does
+// Driver errors are matched by field name, so the package does not depend on any driver
+func errorField(err error, names ...string) (reflect.Value, bool) {
+	if err == nil {
+		return reflect.Value{}, false
+	}
+
+	value := reflect.ValueOf(err)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			break
+		}
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Struct {
+		for _, name := range names {
+			if field := value.FieldByName(name); field.IsValid() {
+				return field, true
+			}
+		}
+	}
+
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		return errorField(wrapped.Unwrap(), names...)
+	case interface{ Unwrap() []error }:
+		for _, inner := range wrapped.Unwrap() {
+			if field, ok := errorField(inner, names...); ok {
+				return field, true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,168 @@
+package gobuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pgError mimics drivers that report the SQLSTATE through a method, like pgx and lib/pq
+type pgError struct{ code string }
+
+func (e *pgError) Error() string    { return "pq: " + e.code }
+func (e *pgError) SQLState() string { return e.code }
+
+// mysqlError mimics go-sql-driver/mysql, which only has fields
+type mysqlError struct {
+	Number   uint16
+	SQLState [5]byte
+	Message  string
+}
+
+func (e *mysqlError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+// mssqlError mimics go-mssqldb
+type mssqlError struct{ number int32 }
+
+func (e mssqlError) Error() string         { return fmt.Sprintf("mssql: error %d", e.number) }
+func (e mssqlError) SQLErrorNumber() int32 { return e.number }
+
+// sqliteError mimics mattn/go-sqlite3, with the extended code in the upper bits
+type sqliteError struct {
+	Code         int
+	ExtendedCode int
+}
+
+func (e sqliteError) Error() string { return "database is locked" }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  SQLDialect
+		err      error
+		expected bool
+	}{
+		{"Postgres Serialization Failure", Postgres, &pgError{code: "40001"}, true},
+		{"Postgres Deadlock Wrapped", Postgres, fmt.Errorf("update: %w", &pgError{code: "40P01"}), true},
+		{"Postgres Unique Violation", Postgres, &pgError{code: "23505"}, false},
+		{"MySQL Deadlock", MySQL, &mysqlError{Number: 1213, SQLState: [5]byte{'4', '0', '0', '0', '1'}}, true},
+		{"MySQL Lock Wait Timeout", MySQL, &mysqlError{Number: 1205}, true},
+		{"MySQL Duplicate Entry", MySQL, &mysqlError{Number: 1062}, false},
+		{"SQL Server Deadlock", SQLServer, mssqlError{number: 1205}, true},
+		{"SQL Server Other", SQLServer, mssqlError{number: 2627}, false},
+		{"SQLite Busy", SQLite, sqliteError{Code: 5}, true},
+		{"SQLite Busy Extended", SQLite, sqliteError{Code: 5 | 1<<8}, true},
+		{"SQLite Constraint", SQLite, sqliteError{Code: 19}, false},
+		{"Any Dialect", "", &mysqlError{Number: 1213}, true},
+		{"MySQL Deadlock Joined", MySQL, errors.Join(errors.New("rollback failed"), &mysqlError{Number: 1213}), true},
+		{"SQLite Busy In DBError", SQLite, &DBError{Kind: ErrUniqueViolation, Err: sqliteError{Code: 5}}, true},
+		{"Plain Error", Postgres, errors.New("40001"), false},
+		{"Nil", Postgres, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.dialect, tt.err); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	calls := 0
+	err := policy.Do(context.Background(), MySQL, func() error {
+		calls++
+		if calls < 3 {
+			return &mysqlError{Number: 1213}
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("expected success after 3 calls, got %v after %d", err, calls)
+	}
+
+	calls = 0
+	err = policy.Do(context.Background(), MySQL, func() error {
+		calls++
+		return &mysqlError{Number: 1205}
+	})
+	if err == nil || calls != 3 {
+		t.Errorf("expected the last error after 3 calls, got %v after %d", err, calls)
+	}
+
+	calls = 0
+	failure := errors.New("syntax error")
+	err = policy.Do(context.Background(), MySQL, func() error {
+		calls++
+		return failure
+	})
+	if !errors.Is(err, failure) || calls != 1 {
+		t.Errorf("expected no retry of %v, got %v after %d calls", failure, err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = (&RetryPolicy{BaseDelay: time.Hour}).Do(ctx, Postgres, func() error { return &pgError{code: "40001"} })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the context error, got %v", err)
+	}
+}
+
+func TestTransact_Retry(t *testing.T) {
+	updates := 0
+	db, fake := newFakeDB(func(query string, args []any) fakeResponse {
+		if strings.HasPrefix(query, "UPDATE") {
+			updates++
+			if updates == 1 {
+				return fakeResponse{err: &pgError{code: "40001"}}
+			}
+		}
+		return fakeResponse{rowsAffected: 1}
+	})
+	defer db.Close()
+
+	opts := &TxOptions{Dialect: Postgres, Retry: &RetryPolicy{BaseDelay: time.Millisecond}}
+	err := Transact(context.Background(), db, opts, func(tx Executor) error {
+		_, err := NewGoBuilder(Postgres).Table("accounts").Increment("balance", 10).Where("id", "=", 1).Exec(context.Background(), tx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	update := "UPDATE accounts SET balance = balance + $1 WHERE id = $2"
+	expected := []string{"BEGIN", update, "ROLLBACK", "BEGIN", update, "COMMIT"}
+	if !reflect.DeepEqual(fake.queries(), expected) {
+		t.Errorf("expected queries %v, got %v", expected, fake.queries())
+	}
+}
+
+func TestTransact_RetryFailedCommit(t *testing.T) {
+	commits := 0
+	db, fake := newFakeDB(func(query string, args []any) fakeResponse {
+		if query == "COMMIT" {
+			commits++
+			if commits == 1 {
+				return fakeResponse{err: sqliteError{Code: 5}}
+			}
+		}
+		return fakeResponse{}
+	})
+	defer db.Close()
+
+	opts := &TxOptions{Dialect: SQLite, Retry: &RetryPolicy{BaseDelay: time.Millisecond}}
+	if err := Transact(context.Background(), db, opts, func(Executor) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"BEGIN", "COMMIT", "BEGIN", "COMMIT"}
+	if !reflect.DeepEqual(fake.queries(), expected) {
+		t.Errorf("expected queries %v, got %v", expected, fake.queries())
+	}
+}
@@ -0,0 +1,63 @@
+package gobuilder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// WithTimeout sets the timeout of the statement run by Exec, Query, Paginate or ClaimBatch, overriding Timeout
+// Besides the context deadline, the timeout is enforced by the database where the dialect supports it:
+// MySQL SELECT statements get a MAX_EXECUTION_TIME optimizer hint, and PostgreSQL runs
+// SET LOCAL statement_timeout first when the executor is a transaction, which then applies to the
+// rest of the transaction. A zero or negative d disables the timeout
+// Example:
+//
+//	builder.Table("reports").Select().WithTimeout(2 * time.Second).Query(ctx, db)
+//	// MySQL: SELECT /*+ MAX_EXECUTION_TIME(2000) */ * FROM reports
+func (gb *GoBuilder) WithTimeout(d time.Duration) *GoBuilder {
+	if d <= 0 {
+		d = -1
+	}
+	gb.timeout = d
+	return gb
+}
+
+// Private method to apply the timeout to a context that has no deadline yet
+func (gb *GoBuilder) timeoutContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := gb.timeout
+	if timeout == 0 {
+		timeout = Timeout
+	}
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Private method to render the MySQL optimizer hint of a timeout set with WithTimeout
+func (gb *GoBuilder) timeoutHint() string {
+	if gb.sqlDialect != MySQL || gb.timeout <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("/*+ MAX_EXECUTION_TIME(%d) */", timeoutMillis(gb.timeout))
+}
+
+// setStatementTimeout runs SET LOCAL statement_timeout for a timeout set with WithTimeout on a PostgreSQL transaction
+func setStatementTimeout(ctx context.Context, ex Executor, dialect SQLDialect, timeout time.Duration) error {
+	if dialect != Postgres || timeout <= 0 {
+		return nil
+	}
+	switch ex.(type) {
+	case *sql.Tx, *Tx:
+		_, err := ex.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", timeoutMillis(timeout)))
+		return err
+	}
+	return nil
+}
+
+// timeoutMillis returns the timeout in whole milliseconds, at least 1
+func timeoutMillis(timeout time.Duration) int64 {
+	return max(timeout.Milliseconds(), 1)
+}
@@ -0,0 +1,121 @@
+package gobuilder
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithTimeout_MySQLHint(t *testing.T) {
+	tests := []sqlTestCase{
+		{
+			name:    "Select",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("reports").Select("id").Where("year", "=", 2024).WithTimeout(2 * time.Second)
+			},
+			expected: "SELECT /*+ MAX_EXECUTION_TIME(2000) */ id FROM reports WHERE year = ?",
+			params:   []any{2024},
+		},
+		{
+			name:    "Distinct",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("reports").SelectDistinct("year").WithTimeout(500 * time.Millisecond)
+			},
+			expected: "SELECT /*+ MAX_EXECUTION_TIME(500) */ DISTINCT year FROM reports",
+			params:   []any{},
+		},
+		{
+			name:    "Update Has No Hint",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("reports").Update(map[string]any{"year": 2025}).Where("id", "=", 1).WithTimeout(time.Second)
+			},
+			expected: "UPDATE reports SET year = ? WHERE id = ?",
+			params:   []any{2025, 1},
+		},
+		{
+			name:    "Default Timeout Has No Hint",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("reports").Select("id")
+			},
+			expected: "SELECT id FROM reports",
+			params:   []any{},
+		},
+		{
+			name:    "Other Dialects",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("reports").Select("id").WithTimeout(time.Second)
+			},
+			expected: "SELECT id FROM reports",
+			params:   []any{},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestTimeout_ContextDeadline(t *testing.T) {
+	db, fake := newFakeDB(nil)
+	defer db.Close()
+
+	ctx := context.Background()
+	start := time.Now()
+	if _, err := NewGoBuilder(Postgres).Table("users").Select().Query(ctx, db); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := fake.deadline(0).Sub(start); d < Timeout-time.Second || d > Timeout+time.Second {
+		t.Errorf("expected the default %v timeout, got %v", Timeout, d)
+	}
+
+	if _, err := NewGoBuilder(Postgres).Table("users").Delete().WithTimeout(time.Second).Exec(ctx, db); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := fake.deadline(1).Sub(start); d < time.Second/2 || d > 2*time.Second {
+		t.Errorf("expected a 1s timeout, got %v", d)
+	}
+
+	if _, err := NewGoBuilder(Postgres).Table("users").Select().WithTimeout(0).Query(ctx, db); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !fake.deadline(2).IsZero() {
+		t.Errorf("expected no deadline, got %v", fake.deadline(2))
+	}
+
+	deadline := start.Add(time.Hour)
+	withDeadline, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	if _, err := NewGoBuilder(Postgres).Table("users").Select().WithTimeout(time.Second).Query(withDeadline, db); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !fake.deadline(3).Equal(deadline) {
+		t.Errorf("expected the caller's deadline %v, got %v", deadline, fake.deadline(3))
+	}
+}
+
+func TestTimeout_PostgresStatementTimeout(t *testing.T) {
+	db, fake := newFakeDB(nil)
+	defer db.Close()
+
+	ctx := context.Background()
+	err := Transact(ctx, db, &TxOptions{Dialect: Postgres}, func(tx Executor) error {
+		_, err := NewGoBuilder(Postgres).Table("reports").Select().WithTimeout(1500*time.Millisecond).Query(ctx, tx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := NewGoBuilder(Postgres).Table("reports").Select().WithTimeout(time.Second).Query(ctx, db); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"BEGIN", "SET LOCAL statement_timeout = 1500", "SELECT * FROM reports", "COMMIT", "SELECT * FROM reports"}
+	if !reflect.DeepEqual(fake.queries(), expected) {
+		t.Errorf("expected queries %v, got %v", expected, fake.queries())
+	}
+}
@@ -0,0 +1,131 @@
+package gobuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Descendants turns the builder into a query over the subtree below rootID, including the root itself
+// It generates a recursive CTE named "<table>_tree" with the columns idCol, parentCol, depth and path,
+// where depth is 0 for the root and path is the "/" separated list of ids from the root
+// The result can be filtered further or used in FromSub, Where and JoinSub
+// Parameters:
+//   - table: The table holding the hierarchy
+//   - idCol: The primary key column
+//   - parentCol: The column referencing the parent row
+//   - rootID: The id of the row to start from, bound as a parameter
+//
+// Returns:
+//   - *GoBuilder: The builder instance for method chaining
+//
+// Example:
+//
+//	builder.Descendants("categories", "id", "parent_id", 1).Where("depth", "<=", 2)
+//	// Generates: WITH RECURSIVE categories_tree (id, parent_id, depth, path) AS (
+//	//   SELECT id, parent_id, 0, CAST(id AS TEXT) FROM categories WHERE id = $1
+//	//   UNION ALL
+//	//   SELECT t.id, t.parent_id, categories_tree.depth + 1, categories_tree.path || '/' || CAST(t.id AS TEXT)
+//	//   FROM categories t INNER JOIN categories_tree ON t.parent_id = categories_tree.id
+//	// ) SELECT * FROM categories_tree WHERE depth <= $2
+func (gb *GoBuilder) Descendants(table, idCol, parentCol string, rootID any) *GoBuilder {
+	return gb.tree(table, idCol, parentCol, rootID, false)
+}
+
+// Ancestors turns the builder into a query over the chain of parents above rootID, including the row itself
+// It produces the same columns as Descendants; depth grows towards the top of the hierarchy
+func (gb *GoBuilder) Ancestors(table, idCol, parentCol string, rootID any) *GoBuilder {
+	return gb.tree(table, idCol, parentCol, rootID, true)
+}
+
+// DescendantsConnectBy is the Oracle CONNECT BY alternative of Descendants
+// Example:
+//
+//	builder.DescendantsConnectBy("categories", "id", "parent_id", 1)
+//	// Generates: SELECT * FROM (SELECT id, parent_id, LEVEL - 1 AS depth, LTRIM(SYS_CONNECT_BY_PATH(id, '/'), '/') AS path
+//	//   FROM categories START WITH id = :1 CONNECT BY PRIOR id = parent_id) categories_tree
+func (gb *GoBuilder) DescendantsConnectBy(table, idCol, parentCol string, rootID any) *GoBuilder {
+	return gb.connectBy(table, idCol, parentCol, rootID, false)
+}
+
+// AncestorsConnectBy is the Oracle CONNECT BY alternative of Ancestors
+func (gb *GoBuilder) AncestorsConnectBy(table, idCol, parentCol string, rootID any) *GoBuilder {
+	return gb.connectBy(table, idCol, parentCol, rootID, true)
+}
+
+// Private method to build the recursive CTE used by Descendants and Ancestors
+func (gb *GoBuilder) tree(table, idCol, parentCol string, rootID any, up bool) *GoBuilder {
+	table, idCol, parentCol = sanitizeIdentifier(table), sanitizeIdentifier(idCol), sanitizeIdentifier(parentCol)
+	name := treeName(table)
+	anchorPath, stepPath := gb.treePath(idCol, name)
+
+	// Alt satırlar için çocuk -> ebeveyn, üst satırlar için ebeveyn -> çocuk bağlantısı
+	join := fmt.Sprintf("t.%s = %s.%s", parentCol, name, idCol)
+	if up {
+		join = fmt.Sprintf("t.%s = %s.%s", idCol, name, parentCol)
+	}
+
+	anchor := fmt.Sprintf(
+		"SELECT %s, %s, 0, %s FROM %s WHERE %s = %s",
+		idCol, parentCol, anchorPath, table, idCol, gb.addParam(rootID),
+	)
+	recursive := fmt.Sprintf(
+		"SELECT t.%s, t.%s, %s.depth + 1, %s FROM %s t INNER JOIN %s ON %s",
+		idCol, parentCol, name, stepPath, table, name, join,
+	)
+
+	gb.addCTE(name, []string{idCol, parentCol, "depth", "path"}, "", fmt.Sprintf("%s UNION ALL %s", anchor, recursive))
+	gb.recursiveCTE = true
+	gb.tableClause = name
+	gb.selectClause = fmt.Sprintf("SELECT * FROM %s", name)
+	return gb
+}
+
+// Private method to build the CONNECT BY query used by DescendantsConnectBy and AncestorsConnectBy
+func (gb *GoBuilder) connectBy(table, idCol, parentCol string, rootID any, up bool) *GoBuilder {
+	if gb.sqlDialect != Oracle {
+		gb.err = fmt.Errorf("CONNECT BY is only supported in Oracle")
+		return gb
+	}
+
+	table, idCol, parentCol = sanitizeIdentifier(table), sanitizeIdentifier(idCol), sanitizeIdentifier(parentCol)
+	prior := fmt.Sprintf("PRIOR %s = %s", idCol, parentCol)
+	if up {
+		prior = fmt.Sprintf("PRIOR %s = %s", parentCol, idCol)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s, %s, LEVEL - 1 AS depth, LTRIM(SYS_CONNECT_BY_PATH(%s, '/'), '/') AS path FROM %s START WITH %s = %s CONNECT BY %s",
+		idCol, parentCol, idCol, table, idCol, gb.addParam(rootID), prior,
+	)
+
+	gb.tableClause = fmt.Sprintf("(%s) %s", query, treeName(table))
+	gb.selectClause = fmt.Sprintf("SELECT * FROM %s", gb.tableClause)
+	return gb
+}
+
+// Private method to build the path expressions of the anchor and recursive queries
+// The anchor type must be wide enough for the whole path, and SQL Server requires both types to match exactly
+func (gb *GoBuilder) treePath(idCol, name string) (string, string) {
+	switch gb.sqlDialect {
+	case MySQL:
+		return fmt.Sprintf("CAST(%s AS CHAR(1000))", idCol),
+			fmt.Sprintf("CONCAT(%s.path, '/', t.%s)", name, idCol)
+	case SQLServer:
+		return fmt.Sprintf("CAST(%s AS NVARCHAR(MAX))", idCol),
+			fmt.Sprintf("CAST(%s.path + '/' + CAST(t.%s AS NVARCHAR(MAX)) AS NVARCHAR(MAX))", name, idCol)
+	case Oracle:
+		return fmt.Sprintf("CAST(%s AS VARCHAR2(4000))", idCol),
+			fmt.Sprintf("%s.path || '/' || t.%s", name, idCol)
+	default:
+		return fmt.Sprintf("CAST(%s AS TEXT)", idCol),
+			fmt.Sprintf("%s.path || '/' || CAST(t.%s AS TEXT)", name, idCol)
+	}
+}
+
+// treeName returns the name of the CTE generated for a hierarchy table, without any schema prefix
+func treeName(table string) string {
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		table = table[i+1:]
+	}
+	return table + "_tree"
+}
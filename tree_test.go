@@ -0,0 +1,93 @@
+package gobuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTree(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dialect  SQLDialect
+		builder  func(gb *GoBuilder) (string, []any)
+		expected string
+		params   []any
+	}{
+		{
+			name:    "PostgreSQL Descendants",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) (string, []any) {
+				return gb.Descendants("categories", "id", "parent_id", 1).Where("depth", "<=", 2).OrderBy("path").Prepare()
+			},
+			expected: "WITH RECURSIVE categories_tree (id, parent_id, depth, path) AS (SELECT id, parent_id, 0, CAST(id AS TEXT) FROM categories WHERE id = $1 UNION ALL SELECT t.id, t.parent_id, categories_tree.depth + 1, categories_tree.path || '/' || CAST(t.id AS TEXT) FROM categories t INNER JOIN categories_tree ON t.parent_id = categories_tree.id) SELECT * FROM categories_tree WHERE depth <= $2 ORDER BY path ASC",
+			params:   []any{1, 2},
+		},
+		{
+			name:    "MySQL Ancestors",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) (string, []any) {
+				return gb.Ancestors("employees", "id", "manager_id", 42).Prepare()
+			},
+			expected: "WITH RECURSIVE employees_tree (id, manager_id, depth, path) AS (SELECT id, manager_id, 0, CAST(id AS CHAR(1000)) FROM employees WHERE id = ? UNION ALL SELECT t.id, t.manager_id, employees_tree.depth + 1, CONCAT(employees_tree.path, '/', t.id) FROM employees t INNER JOIN employees_tree ON t.id = employees_tree.manager_id) SELECT * FROM employees_tree",
+			params:   []any{42},
+		},
+		{
+			name:    "Descendants In Where",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) (string, []any) {
+				subtree := NewGoBuilder(Postgres).Descendants("categories", "id", "parent_id", 5).Select("id")
+				return gb.Table("products").Select("name").Where("active", "=", true).Where("category_id", "IN", subtree).Prepare()
+			},
+			expected: "SELECT name FROM products WHERE active = $1 AND category_id IN (WITH RECURSIVE categories_tree (id, parent_id, depth, path) AS (SELECT id, parent_id, 0, CAST(id AS TEXT) FROM categories WHERE id = $2 UNION ALL SELECT t.id, t.parent_id, categories_tree.depth + 1, categories_tree.path || '/' || CAST(t.id AS TEXT) FROM categories t INNER JOIN categories_tree ON t.parent_id = categories_tree.id) SELECT id FROM categories_tree)",
+			params:   []any{true, 5},
+		},
+		{
+			name:    "SQL Server Descendants Hoisted From FromSub",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) (string, []any) {
+				subtree := NewGoBuilder(SQLServer).Descendants("categories", "id", "parent_id", 5)
+				return gb.FromSub(subtree, "c").Select("c.id", "c.depth").Where("c.depth", ">", 0).Prepare()
+			},
+			expected: "WITH categories_tree (id, parent_id, depth, path) AS (SELECT id, parent_id, 0, CAST(id AS NVARCHAR(MAX)) FROM categories WHERE id = @1 UNION ALL SELECT t.id, t.parent_id, categories_tree.depth + 1, CAST(categories_tree.path + '/' + CAST(t.id AS NVARCHAR(MAX)) AS NVARCHAR(MAX)) FROM categories t INNER JOIN categories_tree ON t.parent_id = categories_tree.id) SELECT c.id, c.depth FROM (SELECT * FROM categories_tree) AS c WHERE c.depth > @2",
+			params:   []any{5, 0},
+		},
+		{
+			name:    "Oracle Connect By",
+			dialect: Oracle,
+			builder: func(gb *GoBuilder) (string, []any) {
+				return gb.DescendantsConnectBy("categories", "id", "parent_id", 1).Where("depth", "<=", 3).Prepare()
+			},
+			expected: "SELECT * FROM (SELECT id, parent_id, LEVEL - 1 AS depth, LTRIM(SYS_CONNECT_BY_PATH(id, '/'), '/') AS path FROM categories START WITH id = :1 CONNECT BY PRIOR id = parent_id) categories_tree WHERE depth <= :2",
+			params:   []any{1, 3},
+		},
+		{
+			name:    "Oracle Ancestors Connect By",
+			dialect: Oracle,
+			builder: func(gb *GoBuilder) (string, []any) {
+				return gb.AncestorsConnectBy("categories", "id", "parent_id", 9).Prepare()
+			},
+			expected: "SELECT * FROM (SELECT id, parent_id, LEVEL - 1 AS depth, LTRIM(SYS_CONNECT_BY_PATH(id, '/'), '/') AS path FROM categories START WITH id = :1 CONNECT BY PRIOR parent_id = id) categories_tree",
+			params:   []any{9},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, params := tc.builder(NewGoBuilder(tc.dialect))
+			if query != tc.expected {
+				t.Errorf("expected query %v, got %v", tc.expected, query)
+			}
+			if !reflect.DeepEqual(params, tc.params) {
+				t.Errorf("expected params %v, got %v", tc.params, params)
+			}
+		})
+	}
+}
+
+func TestTree_ConnectByRequiresOracle(t *testing.T) {
+	gb := NewGoBuilder(Postgres)
+	gb.DescendantsConnectBy("categories", "id", "parent_id", 1)
+	if gb.Error() == nil {
+		t.Error("expected an error for CONNECT BY outside Oracle")
+	}
+}
@@ -0,0 +1,130 @@
+package gobuilder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// TxOptions configures Transact
+type TxOptions struct {
+	Isolation sql.IsolationLevel // The isolation level, the driver default when zero
+	ReadOnly  bool               // Whether the transaction only reads
+	Dialect   SQLDialect         // The dialect used for savepoints of nested calls, SAVEPOINT syntax when empty
+	Retry     *RetryPolicy       // Runs the whole transaction again after a retryable error, nil to never retry
+}
+
+// Tx is the Executor handed to Transact callbacks
+// Builders run inside the transaction by passing it to Exec, Query or Paginate, and passing it to
+// Transact again nests a savepoint instead of a new transaction
+type Tx struct {
+	*sql.Tx
+	dialect SQLDialect // The dialect of the savepoint statements
+	depth   int        // The number of enclosing savepoints
+}
+
+// Transact runs fn in a transaction, committing when it returns nil and rolling back when it
+// returns an error or panics; the panic is re-raised after the rollback
+// When db is a *Tx or *sql.Tx, fn runs inside a savepoint of that transaction instead, which is
+// rolled back on its own on failure (SAVE TRANSACTION on SQL Server)
+// With a Retry policy, a transaction failing with a serialization failure or deadlock is rolled back
+// and fn runs again in a new transaction, so fn must not have effects outside the database
+// Savepoints are never retried, since the enclosing transaction has to be run again
+// Parameters:
+//   - ctx: Context for the transaction
+//   - db: *sql.DB or *sql.Conn to begin a transaction on, or a *Tx or *sql.Tx to nest in
+//   - opts: Isolation level, read only flag and dialect, or nil for the defaults
+//   - fn: The work to run, given the transaction as Executor
+//
+// Returns:
+//   - error: The error of fn, or of beginning, committing or rolling back
+//
+// Example:
+//
+//	err := Transact(ctx, db, &TxOptions{Dialect: Postgres}, func(tx Executor) error {
+//	    if _, err := NewGoBuilder(Postgres).Table("accounts").Decrement("balance", 10).Where("id", "=", 1).Exec(ctx, tx); err != nil {
+//	        return err
+//	    }
+//	    return Transact(ctx, tx, nil, func(tx Executor) error { ... }) // SAVEPOINT gobuilder_sp_1
+//	})
+func Transact(ctx context.Context, db Executor, opts *TxOptions, fn func(tx Executor) error) error {
+	if opts == nil {
+		opts = &TxOptions{}
+	}
+
+	switch ex := db.(type) {
+	case *Tx:
+		return ex.savepoint(ctx, fn)
+	case *sql.Tx:
+		return (&Tx{Tx: ex, dialect: opts.Dialect}).savepoint(ctx, fn)
+	case txBeginner:
+		if opts.Retry != nil {
+			return opts.Retry.Do(ctx, opts.Dialect, func() error { return transact(ctx, ex, opts, fn) })
+		}
+		return transact(ctx, ex, opts, fn)
+	default:
+		return fmt.Errorf("cannot begin a transaction on %T", db)
+	}
+}
+
+// transact runs fn in a new transaction
+func transact(ctx context.Context, db txBeginner, opts *TxOptions, fn func(tx Executor) error) error {
+	sqlTx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: opts.Isolation, ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return err
+	}
+	tx := &Tx{Tx: sqlTx, dialect: opts.Dialect}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = sqlTx.Rollback()
+			panic(p)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rbErr := sqlTx.Rollback(); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+	return sqlTx.Commit()
+}
+
+// savepoint runs fn inside a savepoint of the transaction
+func (tx *Tx) savepoint(ctx context.Context, fn func(tx Executor) error) error {
+	nested := &Tx{Tx: tx.Tx, dialect: tx.dialect, depth: tx.depth + 1}
+	name := fmt.Sprintf("gobuilder_sp_%d", nested.depth)
+
+	create, rollback, release := "SAVEPOINT "+name, "ROLLBACK TO SAVEPOINT "+name, "RELEASE SAVEPOINT "+name
+	switch tx.dialect {
+	case SQLServer:
+		create, rollback, release = "SAVE TRANSACTION "+name, "ROLLBACK TRANSACTION "+name, ""
+	case Oracle:
+		// Oracle releases savepoints when the transaction ends
+		release = ""
+	}
+
+	if _, err := tx.ExecContext(ctx, create); err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = tx.ExecContext(ctx, rollback)
+			panic(p)
+		}
+	}()
+	if err := fn(nested); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, rollback); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+
+	if release != "" {
+		_, err := tx.ExecContext(ctx, release)
+		return err
+	}
+	return nil
+}
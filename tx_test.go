@@ -0,0 +1,120 @@
+package gobuilder
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTransact_Commit(t *testing.T) {
+	db, fake := newFakeDB(nil)
+	defer db.Close()
+
+	err := Transact(context.Background(), db, nil, func(tx Executor) error {
+		_, err := NewGoBuilder(Postgres).Table("accounts").Update(map[string]any{"balance": 10}).Where("id", "=", 1).Exec(context.Background(), tx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"BEGIN", "UPDATE accounts SET balance = $1 WHERE id = $2", "COMMIT"}
+	if !reflect.DeepEqual(fake.queries(), expected) {
+		t.Errorf("expected queries %v, got %v", expected, fake.queries())
+	}
+}
+
+func TestTransact_RollbackOnError(t *testing.T) {
+	db, fake := newFakeDB(nil)
+	defer db.Close()
+
+	failure := errors.New("insufficient funds")
+	err := Transact(context.Background(), db, nil, func(tx Executor) error {
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected %v, got %v", failure, err)
+	}
+
+	expected := []string{"BEGIN", "ROLLBACK"}
+	if !reflect.DeepEqual(fake.queries(), expected) {
+		t.Errorf("expected queries %v, got %v", expected, fake.queries())
+	}
+}
+
+func TestTransact_RollbackOnPanic(t *testing.T) {
+	db, fake := newFakeDB(nil)
+	defer db.Close()
+
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("expected the panic to be re-raised, got %v", p)
+		}
+		expected := []string{"BEGIN", "ROLLBACK"}
+		if !reflect.DeepEqual(fake.queries(), expected) {
+			t.Errorf("expected queries %v, got %v", expected, fake.queries())
+		}
+	}()
+
+	_ = Transact(context.Background(), db, nil, func(tx Executor) error {
+		panic("boom")
+	})
+}
+
+func TestTransact_Savepoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  SQLDialect
+		expected []string
+	}{
+		{
+			name:    "Postgres",
+			dialect: Postgres,
+			expected: []string{
+				"BEGIN",
+				"SAVEPOINT gobuilder_sp_1", "RELEASE SAVEPOINT gobuilder_sp_1",
+				"SAVEPOINT gobuilder_sp_1", "SAVEPOINT gobuilder_sp_2", "ROLLBACK TO SAVEPOINT gobuilder_sp_2",
+				"RELEASE SAVEPOINT gobuilder_sp_1",
+				"COMMIT",
+			},
+		},
+		{
+			name:    "SQL Server",
+			dialect: SQLServer,
+			expected: []string{
+				"BEGIN",
+				"SAVE TRANSACTION gobuilder_sp_1",
+				"SAVE TRANSACTION gobuilder_sp_1", "SAVE TRANSACTION gobuilder_sp_2", "ROLLBACK TRANSACTION gobuilder_sp_2",
+				"COMMIT",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, fake := newFakeDB(nil)
+			defer db.Close()
+
+			ctx := context.Background()
+			failure := errors.New("skip")
+			err := Transact(ctx, db, &TxOptions{Dialect: tt.dialect}, func(tx Executor) error {
+				if err := Transact(ctx, tx, nil, func(Executor) error { return nil }); err != nil {
+					return err
+				}
+				return Transact(ctx, tx, nil, func(tx Executor) error {
+					if err := Transact(ctx, tx, nil, func(Executor) error { return failure }); !errors.Is(err, failure) {
+						t.Errorf("expected %v, got %v", failure, err)
+					}
+					return nil
+				})
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(fake.queries(), tt.expected) {
+				t.Errorf("expected queries %v, got %v", tt.expected, fake.queries())
+			}
+		})
+	}
+}
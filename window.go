@@ -0,0 +1,288 @@
+package gobuilder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FrameBound is one end of a window frame, such as UNBOUNDED PRECEDING or 3 FOLLOWING
+type FrameBound string
+
+const (
+	UnboundedPreceding FrameBound = "UNBOUNDED PRECEDING"
+	CurrentRow         FrameBound = "CURRENT ROW"
+	UnboundedFollowing FrameBound = "UNBOUNDED FOLLOWING"
+)
+
+// Preceding returns the frame bound "n PRECEDING"
+func Preceding(n int) FrameBound {
+	return FrameBound(fmt.Sprintf("%d PRECEDING", n))
+}
+
+// Following returns the frame bound "n FOLLOWING"
+func Following(n int) FrameBound {
+	return FrameBound(fmt.Sprintf("%d FOLLOWING", n))
+}
+
+// Frame is the extent of a window frame, used with Rows and Range
+type Frame string
+
+// Between returns the frame extent "BETWEEN start AND end"
+func Between(start, end FrameBound) Frame {
+	return Frame(fmt.Sprintf("BETWEEN %s AND %s", start, end))
+}
+
+// WindowSpec describes the partitioning, ordering and frame of a window
+// It is used for named windows registered with GoBuilder.NamedWindow and by WindowExpr
+type WindowSpec struct {
+	base      string        // The named window this specification extends
+	partition []any         // PARTITION BY terms, strings or expressions
+	order     []windowOrder // ORDER BY terms with their direction
+	frame     string        // The ROWS or RANGE frame clause
+}
+
+// windowOrder is an ORDER BY term of a window
+type windowOrder struct {
+	term any
+	desc bool
+}
+
+// NewWindowSpec creates an empty window specification
+func NewWindowSpec() *WindowSpec {
+	return &WindowSpec{}
+}
+
+// PartitionBy adds PARTITION BY terms
+func (ws *WindowSpec) PartitionBy(columns ...any) *WindowSpec {
+	ws.partition = append(ws.partition, columns...)
+	return ws
+}
+
+// OrderBy adds ascending ORDER BY terms; terms built with Asc or Desc keep their own direction
+func (ws *WindowSpec) OrderBy(columns ...any) *WindowSpec {
+	for _, column := range columns {
+		ws.order = append(ws.order, windowOrder{term: column})
+	}
+	return ws
+}
+
+// OrderByDesc adds descending ORDER BY terms
+func (ws *WindowSpec) OrderByDesc(columns ...any) *WindowSpec {
+	for _, column := range columns {
+		ws.order = append(ws.order, windowOrder{term: column, desc: true})
+	}
+	return ws
+}
+
+// Rows sets a ROWS frame, e.g. Rows(Between(UnboundedPreceding, CurrentRow))
+func (ws *WindowSpec) Rows(frame Frame) *WindowSpec {
+	ws.frame = "ROWS " + string(frame)
+	return ws
+}
+
+// Range sets a RANGE frame
+func (ws *WindowSpec) Range(frame Frame) *WindowSpec {
+	ws.frame = "RANGE " + string(frame)
+	return ws
+}
+
+// Private method to render the contents of the specification without parentheses
+func (ws *WindowSpec) render(gb *GoBuilder) string {
+	parts := make([]string, 0, 4)
+	if ws.base != "" {
+		parts = append(parts, ws.base)
+	}
+	if len(ws.partition) > 0 {
+		parts = append(parts, "PARTITION BY "+strings.Join(gb.terms(ws.partition), ", "))
+	}
+	if len(ws.order) > 0 {
+		terms := make([]orderTerm, 0, len(ws.order))
+		for _, o := range ws.order {
+			if term, ok := gb.orderTerm(o.term, o.desc); ok {
+				terms = append(terms, term)
+			}
+		}
+		parts = append(parts, "ORDER BY "+gb.orderList(terms))
+	}
+	if ws.frame != "" {
+		parts = append(parts, ws.frame)
+	}
+	return strings.Join(parts, " ")
+}
+
+// WindowExpr is a window function call, "fn OVER (...)"
+type WindowExpr struct {
+	fn    Expr
+	spec  WindowSpec
+	alias string
+}
+
+// Window applies a window to a function or aggregate expression
+// Example:
+//
+//	Window(Sum(Col("amount"))).PartitionBy(Col("account_id")).OrderBy(Col("created_at")).
+//	    Rows(Between(UnboundedPreceding, CurrentRow)).As("balance")
+//	// Generates: SUM(amount) OVER (PARTITION BY account_id ORDER BY created_at ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) as balance
+func Window(fn Expr) *WindowExpr {
+	return &WindowExpr{fn: fn}
+}
+
+// Over makes the window refer to a named window registered with GoBuilder.NamedWindow
+// Further PartitionBy, OrderBy and frame calls extend the named window
+func (w *WindowExpr) Over(name string) *WindowExpr {
+	copied := w.clone()
+	copied.spec.base = sanitizeIdentifier(name)
+	return copied
+}
+
+// PartitionBy adds PARTITION BY terms
+func (w *WindowExpr) PartitionBy(columns ...any) *WindowExpr {
+	copied := w.clone()
+	copied.spec.PartitionBy(columns...)
+	return copied
+}
+
+// OrderBy adds ascending ORDER BY terms
+func (w *WindowExpr) OrderBy(columns ...any) *WindowExpr {
+	copied := w.clone()
+	copied.spec.OrderBy(columns...)
+	return copied
+}
+
+// OrderByDesc adds descending ORDER BY terms
+func (w *WindowExpr) OrderByDesc(columns ...any) *WindowExpr {
+	copied := w.clone()
+	copied.spec.OrderByDesc(columns...)
+	return copied
+}
+
+// Rows sets a ROWS frame
+func (w *WindowExpr) Rows(frame Frame) *WindowExpr {
+	copied := w.clone()
+	copied.spec.Rows(frame)
+	return copied
+}
+
+// Range sets a RANGE frame
+func (w *WindowExpr) Range(frame Frame) *WindowExpr {
+	copied := w.clone()
+	copied.spec.Range(frame)
+	return copied
+}
+
+// As sets the alias used when the window expression appears in a SELECT list
+func (w *WindowExpr) As(alias string) *WindowExpr {
+	copied := w.clone()
+	copied.alias = sanitizeIdentifier(alias)
+	return copied
+}
+
+// Private method to copy the expression, so methods return a new expression as Expression methods do
+// The term slices are clipped, so appending to the copy never writes into the receiver's arrays
+func (w *WindowExpr) clone() *WindowExpr {
+	copied := *w
+	copied.spec.partition = slices.Clip(w.spec.partition)
+	copied.spec.order = slices.Clip(w.spec.order)
+	return &copied
+}
+
+func (w *WindowExpr) toSQL(gb *GoBuilder) string {
+	fn := w.fn.toSQL(gb)
+	spec := w.spec.render(gb)
+	if spec == w.spec.base && spec != "" {
+		return fmt.Sprintf("%s OVER %s", fn, spec)
+	}
+	return fmt.Sprintf("%s OVER (%s)", fn, spec)
+}
+
+func (w *WindowExpr) aliasName() string {
+	return w.alias
+}
+
+// RowNumber returns ROW_NUMBER()
+func RowNumber() *Expression {
+	return Func("ROW_NUMBER")
+}
+
+// Rank returns RANK()
+func Rank() *Expression {
+	return Func("RANK")
+}
+
+// DenseRank returns DENSE_RANK()
+func DenseRank() *Expression {
+	return Func("DENSE_RANK")
+}
+
+// Lag returns LAG(expr, offset), the value of expr offset rows before the current row
+func Lag(expr Expr, offset int) *Expression {
+	return offsetFunc("LAG", expr, offset)
+}
+
+// Lead returns LEAD(expr, offset), the value of expr offset rows after the current row
+func Lead(expr Expr, offset int) *Expression {
+	return offsetFunc("LEAD", expr, offset)
+}
+
+// offsetFunc renders LAG and LEAD; the offset is inlined since several dialects require a constant
+func offsetFunc(name string, expr Expr, offset int) *Expression {
+	return &Expression{render: func(gb *GoBuilder) string {
+		return fmt.Sprintf("%s(%s, %d)", name, expr.toSQL(gb), offset)
+	}}
+}
+
+// Filter restricts the rows an aggregate sees, "SUM(x) FILTER (WHERE ...)"
+// PostgreSQL and SQLite render FILTER natively, other dialects get the CASE equivalent,
+// e.g. SUM(CASE WHEN ... THEN x END) and COUNT(CASE WHEN ... THEN 1 END)
+// Example:
+//
+//	Count().Filter(Cond().Where("status", "=", "paid")).As("paid_orders")
+//	// Generates: COUNT(*) FILTER (WHERE status = $1) as paid_orders
+func (e *Expression) Filter(cond *Condition) *Expression {
+	filtered := *e
+	filtered.render = func(gb *GoBuilder) string {
+		if e.aggregate == "" {
+			gb.err = fmt.Errorf("FILTER requires an aggregate expression")
+			return e.render(gb)
+		}
+		if cond.err != nil {
+			gb.err = cond.err
+		}
+
+		switch gb.sqlDialect {
+		case Postgres, SQLite:
+			aggregate := e.render(gb)
+			return fmt.Sprintf("%s FILTER (WHERE %s)", aggregate, gb.absorb(cond.clause, cond.params))
+		default:
+			predicate := gb.absorb(cond.clause, cond.params)
+			value := "1"
+			if e.argument != nil {
+				value = e.argument.toSQL(gb)
+			}
+			distinct := ""
+			if e.distinct {
+				distinct = "DISTINCT "
+			}
+			return fmt.Sprintf("%s(%sCASE WHEN %s THEN %s END)", e.aggregate, distinct, predicate, value)
+		}
+	}
+	return &filtered
+}
+
+// NamedWindow adds a named window definition, "WINDOW name AS (...)"
+// Window expressions refer to it with Over(name); SQL Server and Oracle set an error
+// Example:
+//
+//	builder.Table("employees").
+//	    SelectExpr(Col("name"), Window(Rank()).Over("w").As("salary_rank")).
+//	    NamedWindow("w", NewWindowSpec().PartitionBy(Col("department")).OrderByDesc(Col("salary")))
+//	// Generates: SELECT name, RANK() OVER w as salary_rank FROM employees WINDOW w AS (PARTITION BY department ORDER BY salary DESC)
+func (gb *GoBuilder) NamedWindow(name string, spec *WindowSpec) *GoBuilder {
+	if gb.sqlDialect == SQLServer || gb.sqlDialect == Oracle {
+		gb.err = fmt.Errorf("named windows are not supported in %s", gb.sqlDialect)
+		return gb
+	}
+	gb.windowClauses = append(gb.windowClauses, fmt.Sprintf("%s AS (%s)", sanitizeIdentifier(name), spec.render(gb)))
+	return gb
+}
@@ -0,0 +1,114 @@
+package gobuilder
+
+import (
+	"testing"
+)
+
+func TestWindow(t *testing.T) {
+	tests := []sqlTestCase{
+		{
+			name:    "Running Total With Frame",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("payments").SelectExpr(
+					Col("id"),
+					Window(Sum(Col("amount"))).PartitionBy(Col("account_id")).OrderBy(Col("created_at")).
+						Rows(Between(UnboundedPreceding, CurrentRow)).As("balance"),
+				)
+			},
+			expected: "SELECT id, SUM(amount) OVER (PARTITION BY account_id ORDER BY created_at ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) as balance FROM payments",
+			params:   []any{},
+		},
+		{
+			name:    "Ranking And Offsets",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("scores").SelectExpr(
+					Window(RowNumber()).OrderByDesc(Col("points")).As("position"),
+					Window(Lag(Col("points"), 1)).OrderBy(Col("played_at")).As("previous"),
+					Window(Avg(Col("points"))).OrderBy(Col("played_at")).Rows(Between(Preceding(2), Following(2))),
+				)
+			},
+			expected: "SELECT ROW_NUMBER() OVER (ORDER BY points DESC) as position, LAG(points, 1) OVER (ORDER BY played_at ASC) as previous, AVG(points) OVER (ORDER BY played_at ASC ROWS BETWEEN 2 PRECEDING AND 2 FOLLOWING) FROM scores",
+			params:   []any{},
+		},
+		{
+			name:    "Named Window",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("employees").
+					SelectExpr(Col("name"), Window(Rank()).Over("w").As("salary_rank"), Window(Sum(Col("salary"))).Over("w").Rows(Between(UnboundedPreceding, CurrentRow))).
+					Where("active", "=", true).
+					NamedWindow("w", NewWindowSpec().PartitionBy(Col("department")).OrderByDesc(Col("salary"))).
+					OrderBy("name")
+			},
+			expected: "SELECT name, RANK() OVER w as salary_rank, SUM(salary) OVER (w ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) FROM employees WHERE active = $1 WINDOW w AS (PARTITION BY department ORDER BY salary DESC) ORDER BY name ASC",
+			params:   []any{true},
+		},
+		{
+			name:    "Filter Postgres",
+			dialect: Postgres,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").SelectExpr(
+					Count().Filter(Cond().Where("status", "=", "paid")).As("paid"),
+					Sum(Col("total")).Filter(Cond().Where("status", "=", "refunded")).As("refunded"),
+				).Where("year", "=", 2024)
+			},
+			expected: "SELECT COUNT(*) FILTER (WHERE status = $1) as paid, SUM(total) FILTER (WHERE status = $2) as refunded FROM orders WHERE year = $3",
+			params:   []any{"paid", "refunded", 2024},
+		},
+		{
+			name:    "Filter Emulated On MySQL",
+			dialect: MySQL,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").SelectExpr(
+					Count().Filter(Cond().Where("status", "=", "paid")).As("paid"),
+					Window(Sum(Col("total")).Filter(Cond().Where("status", "=", "refunded"))).PartitionBy(Col("user_id")).As("refunded"),
+				)
+			},
+			expected: "SELECT COUNT(CASE WHEN status = ? THEN 1 END) as paid, SUM(CASE WHEN status = ? THEN total END) OVER (PARTITION BY user_id) as refunded FROM orders",
+			params:   []any{"paid", "refunded"},
+		},
+		{
+			name:    "Filter Emulated On SQL Server",
+			dialect: SQLServer,
+			builder: func(gb *GoBuilder) *GoBuilder {
+				return gb.Table("orders").SelectExpr(Sum(Col("total")).Filter(Cond().In("status", "paid", "shipped")).As("revenue"))
+			},
+			expected: "SELECT SUM(CASE WHEN status IN (@1, @2) THEN total END) as revenue FROM orders",
+			params:   []any{"paid", "shipped"},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestWindow_FilterRequiresAggregate(t *testing.T) {
+	gb := NewGoBuilder(Postgres).Table("orders").SelectExpr(Col("total").Filter(Cond().Where("status", "=", "paid")))
+	if gb.Error() == nil {
+		t.Error("expected error when filtering a non aggregate expression")
+	}
+}
+
+func TestWindow_NamedWindowUnsupported(t *testing.T) {
+	for _, dialect := range []SQLDialect{SQLServer, Oracle} {
+		gb := NewGoBuilder(dialect).Table("employees").NamedWindow("w", NewWindowSpec().PartitionBy(Col("department")))
+		if gb.Error() == nil {
+			t.Errorf("expected an error for a named window in %s", dialect)
+		}
+	}
+}
+
+func TestWindow_MethodsReturnCopies(t *testing.T) {
+	base := Window(Sum(Col("amount"))).PartitionBy(Col("account_id"))
+	ordered := base.OrderBy(Col("created_at")).As("balance")
+	desc := base.OrderByDesc(Col("created_at")).As("reverse_balance")
+
+	query, _ := NewGoBuilder(Postgres).Table("entries").SelectExpr(base, ordered, desc).Prepare()
+	expected := "SELECT SUM(amount) OVER (PARTITION BY account_id), " +
+		"SUM(amount) OVER (PARTITION BY account_id ORDER BY created_at ASC) as balance, " +
+		"SUM(amount) OVER (PARTITION BY account_id ORDER BY created_at DESC) as reverse_balance FROM entries"
+	if query != expected {
+		t.Errorf("expected query %v, got %v", expected, query)
+	}
+}